@@ -0,0 +1,188 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// B is a type passed to each benchmark method of a group. Like T, it has methods for testify's
+// assertions, and it embeds the *testing.B, so b.N, b.ResetTimer, b.ReportMetric, and the rest of
+// testing.B's methods work as usual.
+type B struct {
+	*testing.B
+	*assert.Assertions
+	Require *require.Assertions
+}
+
+func newB(b *testing.B) *B {
+	return &B{
+		B:          b,
+		Assertions: assert.New(b),
+		Require:    require.New(b),
+	}
+}
+
+// Run is just like testing.B.Run, but the argument to f is a *testgroup.B instead of a *testing.B.
+func (b *B) Run(name string, f func(b *B)) bool {
+	b.B.Helper()
+	return b.B.Run(name, func(b *testing.B) { f(newB(b)) })
+}
+
+// RunBenchmarks runs the benchmark methods of a group, which have the signature func(*testgroup.B),
+// as sub-benchmarks of b in lexicographic order. The group's test methods don't run.
+//
+// The group's PreGroup and PostGroup methods run once, before and after all of the benchmarks, so
+// benchmarks can share expensive fixtures with the group's tests. If the group has PreBenchmark or
+// PostBenchmark methods with the signature func(*testgroup.B), they run before and after each call
+// to a benchmark method, with the benchmark's timer stopped. If the group has a SkipGroup method,
+// b is skipped when it gives a reason to, like the group's tests.
+func RunBenchmarks(b *testing.B, group interface{}) {
+	b.Helper()
+
+	t := testingB{b}
+
+	methods := findBenchmarkMethods(t, group)
+	if len(methods) == 0 {
+		b.Fatalf(
+			"testgroup: no benchmarks found for %T."+
+				" Make sure your benchmark methods are exported, that their signature is func(*testgroup.B),"+
+				" and that their receiver types match what you passed to testgroup.",
+			group)
+	}
+
+	r := newRunner(t, false, group, newOptions(nil))
+	r.failFast = newFailFast(t, false)
+	r.environment = newEnvironmentGuard(false)
+
+	groupT := newT(t, false, r.result.hooks)
+	groupT.setContext(testContext(t))
+	groupT.environment = r.environment
+	defer groupT.undoEnvironmentChanges()
+
+	r.skipGroupIfNeeded(groupT)
+
+	type preGrouper interface{ PreGroup(t *T) }
+
+	if pg, ok := group.(preGrouper); ok {
+		pg.PreGroup(groupT)
+	}
+
+	type postGrouper interface{ PostGroup(t *T) }
+
+	if pg, ok := group.(postGrouper); ok {
+		defer pg.PostGroup(groupT)
+	}
+
+	for _, m := range methods {
+		method := m
+		b.Run(method.Name, func(b *testing.B) { runBenchmark(b, group, method) })
+	}
+}
+
+// runBenchmark makes one call to a benchmark method, with the group's per-benchmark hooks around
+// it. The testing package calls it once for each b.N that it tries.
+func runBenchmark(b *testing.B, group interface{}, method testMethod) {
+	benchB := newB(b)
+
+	type preBenchmarker interface{ PreBenchmark(b *B) }
+
+	type postBenchmarker interface{ PostBenchmark(b *B) }
+
+	b.StopTimer()
+
+	if pb, ok := group.(preBenchmarker); ok {
+		pb.PreBenchmark(benchB)
+	}
+
+	if pb, ok := group.(postBenchmarker); ok {
+		defer func() {
+			b.StopTimer()
+			pb.PostBenchmark(benchB)
+		}()
+	}
+
+	b.ResetTimer()
+	b.StartTimer()
+	method.Method.Call([]reflect.Value{reflect.ValueOf(benchB)})
+}
+
+// findBenchmarkMethods returns the benchmark methods of a group, skipping its test methods and
+// hooks, which findTestMethods checks.
+func findBenchmarkMethods(t TB, group interface{}) []testMethod {
+	t.Helper()
+
+	benchmarks := []testMethod{}
+
+	groupValue := reflect.ValueOf(group)
+	groupType := groupValue.Type()
+
+	requireGroupAndGroupPtrMethodsToMatch(t, groupType)
+
+	benchmarkSignature := reflect.TypeOf(func(*B) {})
+
+	testingBSignature := reflect.TypeOf(func(*testing.B) {})
+
+	for i := 0; i < groupType.NumMethod(); i++ {
+		name := groupType.Method(i).Name
+
+		if groupValue.Method(i).Type() == testingBSignature {
+			t.Errorf(
+				"testgroup: %v should accept a *testgroup.B, not a *testing.B.",
+				fmt.Sprintf("%v.%v", groupType, name))
+
+			continue
+		}
+
+		if groupValue.Method(i).Type() != benchmarkSignature || isBenchmarkHook(name) {
+			continue
+		}
+
+		benchmarks = append(benchmarks, testMethod{Name: name, Method: groupValue.Method(i)})
+	}
+
+	if t.Failed() {
+		t.Fatal("testgroup: problems finding valid benchmark methods -- see previous failures")
+	}
+
+	return benchmarks
+}
+
+func isBenchmarkHook(name string) bool {
+	return name == "PreBenchmark" || name == "PostBenchmark"
+}
+
+// testingB is the TB for the *testing.B that a benchmark group's PreGroup and PostGroup methods run
+// on. They can't run subtests.
+type testingB struct {
+	*testing.B
+}
+
+func (b testingB) Parallel() {
+	b.B.Helper()
+	b.B.Fatal("testgroup: T.Parallel can't be used in a benchmark group's hooks")
+}
+
+func (b testingB) Run(name string, _ func(t TB)) bool {
+	b.B.Helper()
+	b.B.Fatalf("testgroup: T.Run(%q) can't be used in a benchmark group's hooks", name)
+
+	return false
+}
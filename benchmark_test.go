@@ -0,0 +1,170 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RunBenchmarks(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		return
+	}
+
+	result := testgrouptest.Rerun(t, "-test.bench", "^Benchmark_Group$", "-test.benchtime", "3x")
+	require.Equal(t, 0, result.ExitCode, "combined output:\n%s", result.Output)
+
+	output := result.Output
+
+	assert.Contains(t, output, "events: PreGroup, "+
+		"PreBenchmark, Max(1), PostBenchmark, PreBenchmark, Max(3), PostBenchmark, "+
+		"PreBenchmark, Sum(1), PostBenchmark, PreBenchmark, Sum(3), PostBenchmark, "+
+		"PostGroup")
+
+	for _, name := range []string{"Max", "Sum"} {
+		m := regexp.MustCompile(`Benchmark_Group/` + name + `\S*\s+3\s+([\d.]+) ns/op\s+1000 items/op`).
+			FindStringSubmatch(output)
+		require.NotNil(t, m, "missing the result of %s", name)
+
+		nsPerOp, err := strconv.ParseFloat(m[1], 64)
+		require.NoError(t, err)
+		assert.Less(t, nsPerOp, float64(time.Millisecond), "the hooks of %s should be outside the timer", name)
+	}
+}
+
+func Test_RunBenchmarks_Tests(t *testing.T) {
+	group := &Benchmarks{}
+	testgroup.RunSerially(t, group)
+
+	assert.Equal(t, []string{"PreGroup", "Fixture", "PostGroup"}, group.events)
+}
+
+func Test_RunBenchmarks_TestingBSignature(t *testing.T) {
+	group := &TestingBSignature{}
+	testing.Benchmark(func(b *testing.B) { testgroup.RunBenchmarks(b, group) })
+
+	assert.False(t, group.ran, "the group's benchmarks shouldn't run when one of them has the wrong signature")
+}
+
+func Test_RunBenchmarks_SkipGroup(t *testing.T) {
+	group := &SkippedBenchmarks{}
+	testing.Benchmark(func(b *testing.B) { testgroup.RunBenchmarks(b, group) })
+
+	assert.Equal(t, []string{"SkipGroup"}, group.events)
+}
+
+func Benchmark_Group(b *testing.B) {
+	testgroup.RunBenchmarks(b, &Benchmarks{})
+}
+
+// Benchmarks has a fixture that its tests and benchmarks share. Its per-benchmark hooks are slow,
+// to show that they don't count toward the benchmarks' times.
+type Benchmarks struct {
+	fixture []int
+	events  []string
+}
+
+func (s *Benchmarks) PreGroup(t *testgroup.T) {
+	s.fixture = make([]int, 1000)
+	for i := range s.fixture {
+		s.fixture[i] = i
+	}
+
+	s.events = append(s.events, "PreGroup")
+}
+
+func (s *Benchmarks) PostGroup(t *testgroup.T) {
+	s.events = append(s.events, "PostGroup")
+	t.Logf("events: %s", strings.Join(s.events, ", "))
+}
+
+func (s *Benchmarks) PreBenchmark(b *testgroup.B) {
+	time.Sleep(10 * time.Millisecond)
+	s.events = append(s.events, "PreBenchmark")
+}
+
+func (s *Benchmarks) PostBenchmark(b *testgroup.B) {
+	time.Sleep(10 * time.Millisecond)
+	s.events = append(s.events, "PostBenchmark")
+}
+
+func (s *Benchmarks) Fixture(t *testgroup.T) {
+	t.Len(s.fixture, 1000)
+	s.events = append(s.events, "Fixture")
+}
+
+func (s *Benchmarks) Max(b *testgroup.B) {
+	s.events = append(s.events, fmt.Sprintf("Max(%d)", b.N))
+
+	for i := 0; i < b.N; i++ {
+		largest := 0
+		for _, n := range s.fixture {
+			if n > largest {
+				largest = n
+			}
+		}
+
+		b.Equal(len(s.fixture)-1, largest)
+	}
+
+	b.ReportMetric(float64(len(s.fixture)), "items/op")
+}
+
+func (s *Benchmarks) Sum(b *testgroup.B) {
+	s.events = append(s.events, fmt.Sprintf("Sum(%d)", b.N))
+
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for _, n := range s.fixture {
+			sum += n
+		}
+
+		b.Require.Equal(len(s.fixture)*(len(s.fixture)-1)/2, sum)
+	}
+
+	b.ReportMetric(float64(len(s.fixture)), "items/op")
+}
+
+// TestingBSignature has a benchmark method that accepts a *testing.B by mistake.
+type TestingBSignature struct {
+	ran bool
+}
+
+func (g *TestingBSignature) Valid(b *testgroup.B) { g.ran = true }
+func (g *TestingBSignature) Mistake(b *testing.B) { g.ran = true }
+
+// SkippedBenchmarks is skipped by its SkipGroup method, so neither its hooks nor its benchmarks
+// should run.
+type SkippedBenchmarks struct {
+	events []string
+}
+
+func (g *SkippedBenchmarks) SkipGroup(t *testgroup.T) string {
+	g.events = append(g.events, "SkipGroup")
+	return "needs a benchmark machine"
+}
+
+func (g *SkippedBenchmarks) PreGroup(t *testgroup.T)  { g.events = append(g.events, "PreGroup") }
+func (g *SkippedBenchmarks) Benchmark(b *testgroup.B) { g.events = append(g.events, "Benchmark") }
@@ -0,0 +1,72 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command testgroup-report summarizes the output of "go test -json" by test group.
+//
+// It reads events from standard input and prints, for each package, the pass/fail/skip counts of
+// each group's methods, the slowest methods, and the output of each failed method (including the
+// output of its subtests):
+//
+//	$ go test -json ./... | testgroup-report
+//	FAIL example (0.02s)
+//	  TestParallel (parallel): 2 passed, 1 failed, 0 skipped (0.01s)
+//	    slowest: C (0.01s), A (0.00s)
+//	    --- FAIL: B (0.00s)
+//	        parallel_test.go:17: something went wrong
+//
+// Top-level tests with subtests are treated as groups. If a group was run with RunInParallel, its
+// methods are found under the parent test named by the -parallel-parent flag.
+//
+// testgroup-report exits with status 1 if any package failed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bloomberg/go-testgroup/internal/testjson"
+)
+
+func main() {
+	parallelParent := flag.String(
+		"parallel-parent", "_",
+		"name of the parent test of RunInParallel methods (testgroup.RunInParallelParentTestName)")
+	slowest := flag.Int("slowest", 3, "number of slowest methods to show for each group")
+	flag.Parse()
+
+	failed, err := run(os.Stdin, os.Stdout, os.Stderr, *parallelParent, *slowest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "testgroup-report: %v\n", err)
+		os.Exit(2)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func run(in io.Reader, out, other io.Writer, parallelParent string, slowest int) (bool, error) {
+	r := newReport(parallelParent)
+
+	err := testjson.Decode(in, r.add, func(line string) { fmt.Fprintln(other, line) })
+	if err != nil {
+		return false, err
+	}
+
+	r.write(out, slowest)
+
+	return r.failed(), nil
+}
@@ -0,0 +1,300 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bloomberg/go-testgroup/internal/testjson"
+)
+
+// report collects "go test -json" events and arranges them by package, test group, and test
+// method.
+type report struct {
+	parallelParent string
+
+	packages []*packageReport
+	byName   map[string]*packageReport
+}
+
+type packageReport struct {
+	name    string
+	status  string
+	elapsed float64
+	output  []string
+
+	groups []*groupReport
+	byName map[string]*groupReport
+}
+
+// groupReport is a top-level test. If it has subtests, they are assumed to be testgroup methods.
+type groupReport struct {
+	name     string
+	parallel bool
+	status   string
+	elapsed  float64
+	output   []string
+
+	methods []*methodReport
+	byName  map[string]*methodReport
+}
+
+// methodReport is a test method. Output from the method's own subtests is collapsed into it.
+type methodReport struct {
+	name     string
+	status   string
+	elapsed  float64
+	output   []string
+	attempts int // the number of attempt-N subtests, if testgroup retried the method
+}
+
+func newReport(parallelParent string) *report {
+	return &report{
+		parallelParent: parallelParent,
+		byName:         map[string]*packageReport{},
+	}
+}
+
+func (r *report) add(e testjson.Event) {
+	pkg, ok := r.byName[e.Package]
+	if !ok {
+		pkg = &packageReport{name: e.Package, byName: map[string]*groupReport{}}
+		r.byName[e.Package] = pkg
+		r.packages = append(r.packages, pkg)
+	}
+
+	if e.Test == "" {
+		pkg.add(e)
+		return
+	}
+
+	parts := strings.Split(e.Test, "/")
+
+	group, ok := pkg.byName[parts[0]]
+	if !ok {
+		group = &groupReport{name: parts[0], byName: map[string]*methodReport{}}
+		pkg.byName[parts[0]] = group
+		pkg.groups = append(pkg.groups, group)
+	}
+
+	group.add(e, parts[1:], r.parallelParent)
+}
+
+func (p *packageReport) add(e testjson.Event) {
+	switch {
+	case e.IsFinal():
+		p.status = e.Action
+		p.elapsed = e.Elapsed
+	case e.Action == testjson.ActionOutput:
+		p.output = append(p.output, e.Output)
+	}
+}
+
+func (g *groupReport) add(e testjson.Event, subtestPath []string, parallelParent string) {
+	isGroupEvent := len(subtestPath) == 0
+
+	if len(subtestPath) > 0 && subtestPath[0] == parallelParent {
+		g.parallel = true
+		subtestPath = subtestPath[1:]
+	}
+
+	if len(subtestPath) == 0 {
+		switch {
+		case e.IsFinal() && isGroupEvent:
+			g.status = e.Action
+			g.elapsed = e.Elapsed
+		case e.Action == testjson.ActionOutput && !e.IsFraming():
+			g.output = append(g.output, e.Output)
+		}
+
+		return
+	}
+
+	method, ok := g.byName[subtestPath[0]]
+	if !ok {
+		method = &methodReport{name: subtestPath[0]}
+		g.byName[subtestPath[0]] = method
+		g.methods = append(g.methods, method)
+	}
+
+	switch {
+	case e.IsFinal() && len(subtestPath) == 1:
+		method.status = e.Action
+		method.elapsed = e.Elapsed
+	case e.IsFinal() && len(subtestPath) == 2 && strings.HasPrefix(subtestPath[1], "attempt-"):
+		if n, err := strconv.Atoi(strings.TrimPrefix(subtestPath[1], "attempt-")); err == nil && n > method.attempts {
+			method.attempts = n
+		}
+	case e.Action == testjson.ActionOutput && !e.IsFraming():
+		method.output = append(method.output, e.Output)
+	}
+}
+
+// flaky reports whether the method passed after testgroup retried it.
+func (m *methodReport) flaky() bool {
+	return m.status == testjson.ActionPass && m.attempts > 1
+}
+
+// failed reports whether any package or test in the report failed.
+func (r *report) failed() bool {
+	for _, pkg := range r.packages {
+		if pkg.status == testjson.ActionFail {
+			return true
+		}
+	}
+
+	return false
+}
+
+//------------------------------------------------------------------------------
+
+type counts struct {
+	passed, failed, skipped int
+}
+
+func (c *counts) add(status string) {
+	switch status {
+	case testjson.ActionPass:
+		c.passed++
+	case testjson.ActionFail:
+		c.failed++
+	case testjson.ActionSkip:
+		c.skipped++
+	}
+}
+
+func (c counts) String() string {
+	return fmt.Sprintf("%d passed, %d failed, %d skipped", c.passed, c.failed, c.skipped)
+}
+
+func (r *report) write(w io.Writer, slowest int) {
+	for _, pkg := range r.packages {
+		pkg.write(w, slowest)
+	}
+}
+
+func (p *packageReport) write(w io.Writer, slowest int) {
+	status := map[string]string{
+		testjson.ActionPass: "ok  ",
+		testjson.ActionFail: "FAIL",
+		testjson.ActionSkip: "?   ",
+	}[p.status]
+	if status == "" {
+		status = "??? "
+	}
+
+	fmt.Fprintf(w, "%s %s (%.2fs)\n", status, p.name, p.elapsed)
+
+	var plain counts
+
+	var plainFailures []*groupReport
+
+	for _, group := range p.groups {
+		if len(group.methods) == 0 {
+			plain.add(group.status)
+
+			if group.status == testjson.ActionFail {
+				plainFailures = append(plainFailures, group)
+			}
+
+			continue
+		}
+
+		group.write(w, slowest)
+	}
+
+	if plain != (counts{}) {
+		fmt.Fprintf(w, "  tests outside groups: %v\n", plain)
+
+		for _, test := range plainFailures {
+			fmt.Fprintf(w, "    --- FAIL: %s (%.2fs)\n", test.name, test.elapsed)
+			writeOutput(w, "        ", test.output)
+		}
+	}
+
+	if p.status == testjson.ActionFail && len(p.groups) == 0 {
+		writeOutput(w, "    ", p.output)
+	}
+}
+
+func (g *groupReport) write(w io.Writer, slowest int) {
+	var c counts
+	for _, m := range g.methods {
+		c.add(m.status)
+	}
+
+	mode := "serial"
+	if g.parallel {
+		mode = "parallel"
+	}
+
+	fmt.Fprintf(w, "  %s (%s): %v (%.2fs)\n", g.name, mode, c, g.elapsed)
+
+	if names := g.slowest(slowest); len(names) > 0 {
+		fmt.Fprintf(w, "    slowest: %s\n", strings.Join(names, ", "))
+	}
+
+	for _, m := range g.methods {
+		if m.flaky() {
+			fmt.Fprintf(w, "    flaky: %s (passed on attempt %d)\n", m.name, m.attempts)
+		}
+	}
+
+	if g.status == testjson.ActionFail && len(g.output) > 0 {
+		fmt.Fprintf(w, "    group output:\n")
+		writeOutput(w, "        ", g.output)
+	}
+
+	for _, m := range g.methods {
+		if m.status == testjson.ActionFail {
+			fmt.Fprintf(w, "    --- FAIL: %s (%.2fs)\n", m.name, m.elapsed)
+			writeOutput(w, "        ", m.output)
+		}
+	}
+}
+
+// slowest returns descriptions of the n methods that took the longest to run.
+func (g *groupReport) slowest(n int) []string {
+	methods := make([]*methodReport, 0, len(g.methods))
+
+	for _, m := range g.methods {
+		if m.elapsed > 0 {
+			methods = append(methods, m)
+		}
+	}
+
+	sort.SliceStable(methods, func(i, j int) bool { return methods[i].elapsed > methods[j].elapsed })
+
+	if len(methods) > n {
+		methods = methods[:n]
+	}
+
+	names := make([]string, 0, len(methods))
+	for _, m := range methods {
+		names = append(names, fmt.Sprintf("%s (%.2fs)", m.name, m.elapsed))
+	}
+
+	return names
+}
+
+func writeOutput(w io.Writer, indent string, output []string) {
+	for _, line := range output {
+		fmt.Fprintf(w, "%s%s\n", indent, strings.TrimRight(strings.TrimLeft(line, " "), "\n"))
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testdata/parallel.out was recorded from a RunInParallel group with three methods: A sleeps, B
+// logs a line and fails an assertion in a subtest, and C skips itself.
+func Test_Report(t *testing.T) {
+	in, err := os.Open("testdata/parallel.out")
+	require.NoError(t, err)
+
+	defer in.Close()
+
+	var out, other strings.Builder
+
+	failed, err := run(in, &out, &other, "_", 3)
+	require.NoError(t, err)
+
+	assert.True(t, failed)
+	assert.Empty(t, other.String())
+
+	report := out.String()
+	t.Logf("report:\n%s", report)
+
+	assert.Contains(t, report, "FAIL example")
+	assert.Contains(t, report, "TestParallel (parallel): 1 passed, 1 failed, 1 skipped")
+	assert.Contains(t, report, "slowest: A (0.02s)")
+	assert.Contains(t, report, "--- FAIL: B")
+	assert.Contains(t, report, "about to fail")
+	assert.Contains(t, report, "Test:       \tTestParallel/_/B/sub")
+	assert.Contains(t, report, "tests outside groups: 1 passed, 0 failed, 0 skipped")
+
+	assert.NotContains(t, report, "=== RUN", "framing lines should be dropped")
+	assert.NotContains(t, report, "--- FAIL: A")
+}
+
+func Test_Report_DifferentParallelParent(t *testing.T) {
+	in := strings.NewReader(strings.Join([]string{
+		`{"Action":"run","Package":"p","Test":"TestG"}`,
+		`{"Action":"run","Package":"p","Test":"TestG/~"}`,
+		`{"Action":"run","Package":"p","Test":"TestG/~/A"}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/~/A","Elapsed":0.5}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/~","Elapsed":0.5}`,
+		`{"Action":"pass","Package":"p","Test":"TestG","Elapsed":0.5}`,
+		`{"Action":"pass","Package":"p","Elapsed":0.6}`,
+		`not json`,
+	}, "\n"))
+
+	var out, other strings.Builder
+
+	failed, err := run(in, &out, &other, "~", 3)
+	require.NoError(t, err)
+
+	assert.False(t, failed)
+	assert.Equal(t, "not json\n", other.String())
+	assert.Equal(t,
+		"ok   p (0.60s)\n"+
+			"  TestG (parallel): 1 passed, 0 failed, 0 skipped (0.50s)\n"+
+			"    slowest: A (0.50s)\n",
+		out.String())
+}
+
+func Test_Report_Flaky(t *testing.T) {
+	in := strings.NewReader(strings.Join([]string{
+		`{"Action":"run","Package":"p","Test":"TestG"}`,
+		`{"Action":"run","Package":"p","Test":"TestG/A"}`,
+		`{"Action":"run","Package":"p","Test":"TestG/A/attempt-1"}`,
+		`{"Action":"skip","Package":"p","Test":"TestG/A/attempt-1","Elapsed":0.1}`,
+		`{"Action":"run","Package":"p","Test":"TestG/A/attempt-2"}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/A/attempt-2","Elapsed":0.1}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/A","Elapsed":0.2}`,
+		`{"Action":"run","Package":"p","Test":"TestG/B"}`,
+		`{"Action":"run","Package":"p","Test":"TestG/B/attempt-1"}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/B/attempt-1","Elapsed":0.1}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/B","Elapsed":0.1}`,
+		`{"Action":"pass","Package":"p","Test":"TestG","Elapsed":0.3}`,
+		`{"Action":"pass","Package":"p","Elapsed":0.4}`,
+	}, "\n"))
+
+	var out, other strings.Builder
+
+	failed, err := run(in, &out, &other, "_", 0)
+	require.NoError(t, err)
+
+	assert.False(t, failed)
+	assert.Equal(t,
+		"ok   p (0.40s)\n"+
+			"  TestG (serial): 2 passed, 0 failed, 0 skipped (0.30s)\n"+
+			"    flaky: A (passed on attempt 2)\n",
+		out.String())
+}
+
+func Test_Report_NumberedSubtests(t *testing.T) {
+	// Subtests with numbers for names aren't retry attempts.
+	in := strings.NewReader(strings.Join([]string{
+		`{"Action":"run","Package":"p","Test":"TestG"}`,
+		`{"Action":"run","Package":"p","Test":"TestG/A"}`,
+		`{"Action":"run","Package":"p","Test":"TestG/A/1"}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/A/1","Elapsed":0.1}`,
+		`{"Action":"run","Package":"p","Test":"TestG/A/2"}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/A/2","Elapsed":0.1}`,
+		`{"Action":"run","Package":"p","Test":"TestG/A/3"}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/A/3","Elapsed":0.1}`,
+		`{"Action":"pass","Package":"p","Test":"TestG/A","Elapsed":0.3}`,
+		`{"Action":"pass","Package":"p","Test":"TestG","Elapsed":0.3}`,
+		`{"Action":"pass","Package":"p","Elapsed":0.4}`,
+	}, "\n"))
+
+	var out, other strings.Builder
+
+	failed, err := run(in, &out, &other, "_", 0)
+	require.NoError(t, err)
+
+	assert.False(t, failed)
+	assert.Equal(t,
+		"ok   p (0.40s)\n"+
+			"  TestG (serial): 1 passed, 0 failed, 0 skipped (0.30s)\n",
+		out.String())
+}
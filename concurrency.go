@@ -0,0 +1,122 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"sort"
+	"time"
+)
+
+// MaxConcurrency limits how many of the group's test methods can run at the same time, which is
+// useful when they share something that can't handle the load of go test -parallel tests at once.
+// The limit covers each method's PreTest and PostTest hooks as well as the method itself.
+//
+// Methods that have to wait for a turn still count toward go test -parallel while they wait. With
+// go test -v, each method that waited logs how long it waited.
+func MaxConcurrency(n int) Option {
+	return func(o *options) { o.maxConcurrency = n }
+}
+
+// MaxConcurrencyForTag is like MaxConcurrency, but only limits the test methods that have the given
+// tag. A group tags its methods with a Tags method:
+//
+//	func (*MyGroup) Tags() map[string][]string {
+//		return map[string][]string{
+//			"CreateTable": {"db"},
+//			"DropTable":   {"db"},
+//		}
+//	}
+func MaxConcurrencyForTag(tag string, n int) Option {
+	return func(o *options) {
+		if o.maxConcurrencyForTag == nil {
+			o.maxConcurrencyForTag = map[string]int{}
+		}
+
+		o.maxConcurrencyForTag[tag] = n
+	}
+}
+
+// semaphore limits how many goroutines can hold it at once.
+type semaphore chan struct{}
+
+// limits holds the semaphores that enforce the MaxConcurrency and MaxConcurrencyForTag options.
+type limits struct {
+	group semaphore
+	tags  map[string]semaphore
+}
+
+func newLimits(opts *options) *limits {
+	l := &limits{tags: map[string]semaphore{}}
+
+	if opts.maxConcurrency > 0 {
+		l.group = make(semaphore, opts.maxConcurrency)
+	}
+
+	for tag, n := range opts.maxConcurrencyForTag {
+		if n > 0 {
+			l.tags[tag] = make(semaphore, n)
+		}
+	}
+
+	return l
+}
+
+// acquire waits until a test method is allowed to run. It returns how long it waited and a
+// function to call when the method is done.
+func (l *limits) acquire(method string, decls *declarations) (waited time.Duration, release func()) {
+	sems := []semaphore{}
+	if l.group != nil {
+		sems = append(sems, l.group)
+	}
+
+	// Always acquiring tags in the same order prevents deadlocks.
+	tags := make([]string, 0, len(decls.tags[method]))
+	seen := map[string]bool{}
+
+	for _, tag := range decls.tags[method] {
+		if l.tags[tag] != nil && !seen[tag] {
+			tags = append(tags, tag)
+			seen[tag] = true
+		}
+	}
+
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		sems = append(sems, l.tags[tag])
+	}
+
+	start := time.Now()
+	blocked := false
+
+	for _, sem := range sems {
+		select {
+		case sem <- struct{}{}:
+		default:
+			blocked = true
+			sem <- struct{}{}
+		}
+	}
+
+	if blocked {
+		waited = time.Since(start)
+	}
+
+	return waited, func() {
+		for _, sem := range sems {
+			<-sem
+		}
+	}
+}
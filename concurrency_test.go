@@ -0,0 +1,145 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"flag"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MaxConcurrency(t *testing.T) {
+	g := &Concurrency{}
+	testgroup.RunInParallel(t, g, testgroup.MaxConcurrency(2))
+
+	assert.LessOrEqual(t, g.maxRunning[""], 2)
+
+	if goTestParallel() > 2 {
+		assert.Equal(t, 2, g.maxRunning[""])
+	}
+}
+
+func Test_MaxConcurrencyForTag(t *testing.T) {
+	g := &Concurrency{}
+	testgroup.RunInParallel(t, g, testgroup.MaxConcurrencyForTag("db", 1))
+
+	assert.Equal(t, 1, g.maxRunning["db"])
+
+	if goTestParallel() > 2 {
+		assert.Greater(t, g.maxRunning[""], 1, "untagged methods should not be limited")
+	}
+}
+
+func Test_MaxConcurrency_SkippedMethods(t *testing.T) {
+	g := &SkippedMethods{}
+
+	rec := testgroup.Record("Group", func(t testgroup.TB) {
+		g.rec = t.(*testgroup.Recorder)
+		testgroup.RunInParallelOn(t, g, testgroup.MaxConcurrency(1))
+	})
+
+	parent := rec.Subtest(testgroup.RunInParallelParentTestName)
+	assert.False(t, rec.Failed(), "logs: %v", parent.Subtest("WaitsForUnwritten").Logs())
+	assert.True(t, parent.Subtest("Unwritten").Skipped())
+}
+
+// SkippedMethods has a pending method, which should be skipped without waiting for the group's only
+// concurrency slot, even while WaitsForUnwritten holds it.
+type SkippedMethods struct {
+	rec *testgroup.Recorder
+}
+
+func (*SkippedMethods) Pending() map[string]testgroup.PendingInfo {
+	return map[string]testgroup.PendingInfo{"Unwritten": {Reason: "not written yet"}}
+}
+
+func (g *SkippedMethods) WaitsForUnwritten(t *testgroup.T) {
+	t.Eventually(func() bool {
+		unwritten := g.rec.Subtest(testgroup.RunInParallelParentTestName + "/Unwritten")
+		return unwritten != nil && unwritten.Skipped()
+	}, time.Second, time.Millisecond, "Unwritten should be skipped while this method runs")
+}
+
+func (*SkippedMethods) Unwritten(t *testgroup.T) {}
+
+// goTestParallel returns the value of go test -parallel, which defaults to GOMAXPROCS.
+func goTestParallel() int {
+	n, err := strconv.Atoi(flag.Lookup("test.parallel").Value.String())
+	if err != nil {
+		panic(err)
+	}
+
+	return n
+}
+
+// Concurrency keeps track of how many of its methods run at once, both overall and for methods
+// tagged "db".
+type Concurrency struct {
+	mutex      sync.Mutex
+	running    map[string]int
+	maxRunning map[string]int
+}
+
+func (g *Concurrency) Tags() map[string][]string {
+	return map[string][]string{
+		"DB1": {"db", "slow"},
+		"DB2": {"db"},
+		"DB3": {"db", "db"},
+	}
+}
+
+func (g *Concurrency) PreGroup(t *testgroup.T) {
+	g.running = map[string]int{}
+	g.maxRunning = map[string]int{}
+}
+
+func (g *Concurrency) add(keys []string, n int) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, key := range keys {
+		g.running[key] += n
+		if g.running[key] > g.maxRunning[key] {
+			g.maxRunning[key] = g.running[key]
+		}
+	}
+}
+
+// The limit covers hooks too, so counting starts in PreTest and ends in PostTest.
+func (g *Concurrency) PreTest(t *testgroup.T)  { g.add(g.keys(t), 1) }
+func (g *Concurrency) PostTest(t *testgroup.T) { g.add(g.keys(t), -1) }
+
+func (g *Concurrency) keys(t *testgroup.T) []string {
+	name := t.Name()
+	if name[len(name)-3:len(name)-1] == "DB" {
+		return []string{"", "db"}
+	}
+
+	return []string{""}
+}
+
+func (g *Concurrency) work(t *testgroup.T) { time.Sleep(20 * time.Millisecond) }
+
+func (g *Concurrency) A(t *testgroup.T)   { g.work(t) }
+func (g *Concurrency) B(t *testgroup.T)   { g.work(t) }
+func (g *Concurrency) C(t *testgroup.T)   { g.work(t) }
+func (g *Concurrency) DB1(t *testgroup.T) { g.work(t) }
+func (g *Concurrency) DB2(t *testgroup.T) { g.work(t) }
+func (g *Concurrency) DB3(t *testgroup.T) { g.work(t) }
@@ -0,0 +1,158 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"reflect"
+	"sort"
+)
+
+// declarationSignatures returns the exported methods that a group can have to describe its tests,
+// and their signatures. These methods are not tests.
+func declarationSignatures() map[string]reflect.Type {
+	return map[string]reflect.Type{
+		"Tags":             reflect.TypeOf(func() map[string][]string { return nil }),
+		"Resources":        reflect.TypeOf(func() map[string][]Resource { return nil }),
+		"Serial":           reflect.TypeOf(func() map[string]SerialPhase { return nil }),
+		"DependsOn":        reflect.TypeOf(func() map[string][]string { return nil }),
+		"ExpectedFailures": reflect.TypeOf(func() map[string]string { return nil }),
+		"Pending":          reflect.TypeOf(func() map[string]PendingInfo { return nil }),
+		"SkipGroup":        reflect.TypeOf(func(*T) string { return "" }),
+	}
+}
+
+// declarations holds what a group says about its tests through its declaration methods.
+type declarations struct {
+	// tags maps test method names to their tags.
+	tags map[string][]string
+	// resources maps test method names to the resources they use.
+	resources map[string][]Resource
+	// serial maps the names of test methods that RunInParallel runs serially to when it runs them.
+	serial map[string]SerialPhase
+	// dependsOn maps the names of a scenario's steps to the steps they depend on.
+	dependsOn map[string][]string
+	// expectedFailures maps the names of test methods that are expected to fail to the reasons.
+	expectedFailures map[string]string
+	// pending maps the names of test methods that aren't ready to run to why.
+	pending map[string]PendingInfo
+}
+
+func findDeclarations(t TB, group interface{}, methods []testMethod) *declarations {
+	t.Helper()
+
+	d := &declarations{}
+
+	type tagger interface{ Tags() map[string][]string }
+	if g, ok := group.(tagger); ok {
+		d.tags = g.Tags()
+		requireTestMethodNames(t, group, "Tags", methods, d.tags)
+	}
+
+	type resourceUser interface{ Resources() map[string][]Resource }
+	if g, ok := group.(resourceUser); ok {
+		d.resources = g.Resources()
+		requireTestMethodNames(t, group, "Resources", methods, d.resources)
+	}
+
+	type serializer interface{ Serial() map[string]SerialPhase }
+	if g, ok := group.(serializer); ok {
+		d.serial = g.Serial()
+		requireTestMethodNames(t, group, "Serial", methods, d.serial)
+		requireSerialPhases(t, group, d.serial)
+	}
+
+	type dependent interface{ DependsOn() map[string][]string }
+	if g, ok := group.(dependent); ok {
+		d.dependsOn = g.DependsOn()
+		requireTestMethodNames(t, group, "DependsOn", methods, d.dependsOn)
+		requireDependencies(t, group, methods, d.dependsOn)
+	}
+
+	type failureExpecter interface{ ExpectedFailures() map[string]string }
+	if g, ok := group.(failureExpecter); ok {
+		d.expectedFailures = g.ExpectedFailures()
+		requireTestMethodNames(t, group, "ExpectedFailures", methods, d.expectedFailures)
+	}
+
+	type pendingLister interface{ Pending() map[string]PendingInfo }
+	if g, ok := group.(pendingLister); ok {
+		d.pending = g.Pending()
+		requireTestMethodNames(t, group, "Pending", methods, d.pending)
+	}
+
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	return d
+}
+
+// requireTestMethodNames fails the test if any key of a declaration's map isn't the name of a test
+// method, to catch typos and methods that were renamed or removed.
+func requireTestMethodNames(
+	t TB, group interface{}, declaration string, methods []testMethod, declared interface{},
+) {
+	t.Helper()
+
+	isMethod := map[string]bool{}
+	for _, m := range methods {
+		isMethod[m.Name] = true
+	}
+
+	names := []string{}
+	for _, key := range reflect.ValueOf(declared).MapKeys() {
+		names = append(names, key.String())
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !isMethod[name] {
+			t.Errorf("testgroup: %T.%s mentions %q, which is not a test method.", group, declaration, name)
+		}
+	}
+}
+
+// requireSerialPhases fails the test if a Serial declaration uses a phase that isn't BeforeParallel
+// or AfterParallel.
+func requireSerialPhases(t TB, group interface{}, serial map[string]SerialPhase) {
+	t.Helper()
+
+	names := make([]string, 0, len(serial))
+	for name := range serial {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if phase := serial[name]; phase != BeforeParallel && phase != AfterParallel {
+			t.Errorf(
+				"testgroup: %T.Serial says %q runs in phase %d, but it should be BeforeParallel or AfterParallel.",
+				group, name, phase)
+		}
+	}
+}
+
+// hasTag reports whether a test method has a tag.
+func (d *declarations) hasTag(method, tag string) bool {
+	for _, tg := range d.tags[method] {
+		if tg == tag {
+			return true
+		}
+	}
+
+	return false
+}
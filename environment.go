@@ -0,0 +1,305 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// GuardEnvironment fails a test method or hook that changes the process's environment variables,
+// working directory, or umask without changing them back, and changes them back so that the
+// group's later methods aren't affected. The failure lists what changed.
+//
+// Changes made with T.Setenv and T.Chdir are allowed, since testgroup undoes them. Changes made with
+// the embedded *testing.T's Setenv, like t.T.Setenv, fail the test, since the testing package
+// undoes them in a cleanup, which runs after the test is checked.
+//
+// On systems other than Linux, reading the umask briefly sets it to 0, so files that other
+// goroutines create at the same time aren't protected by it.
+//
+// Changes made by test methods that run in parallel fail the group instead; see RunInParallel.
+func GuardEnvironment() Option {
+	return func(o *options) { o.guardEnvironment = true }
+}
+
+// Setenv sets an environment variable until the test finishes, like testing.T.Setenv. When it's
+// called from PreGroup, the variable stays set until PostGroup returns, and when it's called from
+// PreTest, the variable stays set until PostTest returns.
+//
+// Like testing.T.Setenv, it can't be used by tests that run in parallel, since it affects the whole
+// process.
+func (t *T) Setenv(key, value string) {
+	t.tb.Helper()
+	t.requireNotParallel("Setenv")
+
+	old, wasSet := os.LookupEnv(key)
+
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatalf("testgroup: could not set %s: %v", key, err)
+	}
+
+	t.environment.expect(func(s *processState) { s.env[key] = value })
+
+	t.undo = append(t.undo, func() error {
+		if wasSet {
+			t.environment.expect(func(s *processState) { s.env[key] = old })
+			return os.Setenv(key, old)
+		}
+
+		t.environment.expect(func(s *processState) { delete(s.env, key) })
+
+		return os.Unsetenv(key)
+	})
+}
+
+// Chdir changes the working directory until the test finishes, like testing.T.Chdir. When it's
+// called from PreGroup, the directory stays changed until PostGroup returns, and when it's called
+// from PreTest, the directory stays changed until PostTest returns.
+//
+// Like Setenv, it can't be used by tests that run in parallel.
+func (t *T) Chdir(dir string) {
+	t.tb.Helper()
+	t.requireNotParallel("Chdir")
+
+	old, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("testgroup: could not get the working directory: %v", err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("testgroup: could not change the working directory: %v", err)
+	}
+
+	newDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("testgroup: could not get the working directory: %v", err)
+	}
+
+	t.environment.expect(func(s *processState) { s.dir = newDir })
+
+	t.undo = append(t.undo, func() error {
+		t.environment.expect(func(s *processState) { s.dir = old })
+		return os.Chdir(old)
+	})
+}
+
+func (t *T) requireNotParallel(method string) {
+	t.tb.Helper()
+
+	if t.result != nil && t.result.parallel {
+		t.Fatalf("testgroup: T.%s can't be used by tests that run in parallel, since it affects the whole process."+
+			" Use it in PreGroup, or run the test serially.", method)
+	}
+}
+
+// undoEnvironmentChanges undoes the changes made by Setenv and Chdir, most recent first.
+func (t *T) undoEnvironmentChanges() {
+	t.tb.Helper()
+
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		if err := t.undo[i](); err != nil {
+			t.tb.Errorf("testgroup: could not restore the environment: %v", err)
+		}
+	}
+
+	t.undo = nil
+}
+
+//------------------------------------------------------------------------------
+
+// processState is the part of a process's state that GuardEnvironment watches.
+type processState struct {
+	env   map[string]string
+	dir   string
+	umask int // -1 if unknown
+}
+
+func currentProcessState() processState {
+	s := processState{env: map[string]string{}, umask: currentUmask()}
+
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) == 2 {
+			s.env[parts[0]] = parts[1]
+		}
+	}
+
+	s.dir, _ = os.Getwd()
+
+	return s
+}
+
+// diff describes how the state changed to after, one change per line.
+func (s processState) diff(after processState) []string {
+	var changes []string
+
+	names := []string{}
+	for name := range s.env {
+		names = append(names, name)
+	}
+
+	for name := range after.env {
+		if _, ok := s.env[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		before, wasSet := s.env[name]
+		now, isSet := after.env[name]
+
+		switch {
+		case !wasSet:
+			changes = append(changes, fmt.Sprintf("$%s was set to %q", name, now))
+		case !isSet:
+			changes = append(changes, fmt.Sprintf("$%s was unset (it was %q)", name, before))
+		case before != now:
+			changes = append(changes, fmt.Sprintf("$%s was changed from %q to %q", name, before, now))
+		}
+	}
+
+	if s.dir != after.dir {
+		changes = append(changes, fmt.Sprintf("the working directory was changed from %s to %s", s.dir, after.dir))
+	}
+
+	if s.umask != after.umask {
+		changes = append(changes, fmt.Sprintf("the umask was changed from %#o to %#o", s.umask, after.umask))
+	}
+
+	return changes
+}
+
+// restore changes the process's state back from now to s.
+func (s processState) restore(now processState) error {
+	if err := s.restoreEnv(now); err != nil {
+		return err
+	}
+
+	if s.dir != now.dir {
+		if err := os.Chdir(s.dir); err != nil {
+			return err
+		}
+	}
+
+	if s.umask != now.umask {
+		setUmask(s.umask)
+	}
+
+	return nil
+}
+
+func (s processState) restoreEnv(now processState) error {
+	for name := range now.env {
+		if _, ok := s.env[name]; !ok {
+			if err := os.Unsetenv(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, value := range s.env {
+		if current, ok := now.env[name]; !ok || current != value {
+			if err := os.Setenv(name, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// environmentGuard enforces the GuardEnvironment option.
+type environmentGuard struct {
+	enabled bool
+
+	mutex    sync.Mutex
+	expected processState // the state after the last check or intended change
+}
+
+func newEnvironmentGuard(enabled bool) *environmentGuard {
+	g := &environmentGuard{enabled: enabled}
+	if enabled {
+		g.expected = currentProcessState()
+	}
+
+	return g
+}
+
+// expect notes that testgroup is changing the process's state on purpose.
+func (g *environmentGuard) expect(change func(s *processState)) {
+	if g == nil || !g.enabled {
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	change(&g.expected)
+}
+
+// check fails a test if the process's state changed since it was last checked, and changes it back.
+// what says what made the changes.
+func (g *environmentGuard) check(t TB, result *testResult, what string) {
+	t.Helper()
+
+	if !g.enabled {
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	now := currentProcessState()
+
+	changes := g.expected.diff(now)
+	if len(changes) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("testgroup: %s changed the process's environment without changing it back:\n\n%s",
+		what, strings.Join(changes, "\n"))
+
+	if err := g.expected.restore(now); err != nil {
+		message += fmt.Sprintf("\n\ntestgroup could not restore it either: %v", err)
+		g.expected = currentProcessState()
+	}
+
+	result.recordFailure(message)
+	t.Error(message)
+}
+
+// checkPhase checks the environment after a phase of a test that doesn't run in parallel.
+func (g *environmentGuard) checkPhase(t TB, result *testResult, phase string) {
+	t.Helper()
+
+	if result.parallel {
+		return
+	}
+
+	switch phase {
+	case "":
+		g.check(t, result, "the test")
+	default:
+		g.check(t, result, phase)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_T_Setenv(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	t.Run("Serial", func(t *testing.T) { testgroup.RunSerially(t, &SetenvGroup{dir: dir}) })
+	t.Run("Parallel", func(t *testing.T) { testgroup.RunInParallel(t, &SetenvGroup{dir: dir}) })
+
+	_, set := os.LookupEnv("TESTGROUP_GROUP")
+	assert.False(t, set)
+
+	_, set = os.LookupEnv("TESTGROUP_TEST")
+	assert.False(t, set)
+
+	now, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, wd, now)
+}
+
+type SetenvGroup struct {
+	dir string
+}
+
+func (g *SetenvGroup) PreGroup(t *testgroup.T) {
+	t.Setenv("TESTGROUP_GROUP", "group")
+	t.Chdir(g.dir)
+}
+
+func (g *SetenvGroup) PostGroup(t *testgroup.T) {
+	t.Equal("group", os.Getenv("TESTGROUP_GROUP"))
+}
+
+func (g *SetenvGroup) SeesGroupEnvironment(t *testgroup.T) {
+	t.Equal("group", os.Getenv("TESTGROUP_GROUP"))
+
+	dir, err := os.Getwd()
+	t.Require.NoError(err)
+	t.Equal(g.dir, dir)
+}
+
+func (g *SetenvGroup) Serial() map[string]testgroup.SerialPhase {
+	return map[string]testgroup.SerialPhase{"SetsTestEnvironment": testgroup.AfterParallel}
+}
+
+func (g *SetenvGroup) SetsTestEnvironment(t *testgroup.T) {
+	t.Setenv("TESTGROUP_TEST", "test")
+	t.Setenv("TESTGROUP_GROUP", "test")
+
+	t.Run("Subtest", func(t *testgroup.T) {
+		t.Setenv("TESTGROUP_TEST", "subtest")
+	})
+
+	t.Equal("test", os.Getenv("TESTGROUP_TEST"))
+}
+
+func Test_GuardEnvironment(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		dir := t.TempDir()
+
+		t.Run("Serial", func(t *testing.T) {
+			testgroup.RunSerially(t, &GuardEnvironmentGroup{dir: dir}, testgroup.GuardEnvironment())
+		})
+		t.Run("Parallel", func(t *testing.T) {
+			testgroup.RunInParallel(t, &GuardEnvironmentGroup{dir: dir}, testgroup.GuardEnvironment())
+		})
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.ElementsMatch(t,
+		[]string{
+			"Serial/ChangesDirectory", "Serial/SetsVariable", "Serial",
+			"Parallel/_/UsesSetenv", "Parallel/_", "Parallel",
+		},
+		subtestNames(t, result, testgrouptest.Failed))
+	assert.ElementsMatch(t,
+		[]string{
+			"Serial/UsesSetenv", "Serial/VerifiesRestored",
+			"Parallel/_/ChangesDirectory", "Parallel/_/SetsVariable", "Parallel/VerifiesRestored",
+		},
+		subtestNames(t, result, testgrouptest.Passed))
+
+	setsVariable := subtest(t, result, "Serial/SetsVariable").Output
+	assert.Contains(t, setsVariable, "testgroup: the test changed the process's environment without changing it back:")
+	assert.Contains(t, setsVariable, `$TESTGROUP_LEAK was set to "leak"`)
+
+	changesDirectory := subtest(t, result, "Serial/ChangesDirectory").Output
+	assert.Contains(t, changesDirectory, "testgroup: PostTest changed the process's environment without changing it back:")
+	assert.Contains(t, changesDirectory, "the working directory was changed from ")
+
+	assert.Contains(t, subtest(t, result, "Parallel/_/UsesSetenv").Output,
+		"testgroup: T.Setenv can't be used by tests that run in parallel")
+	assert.Contains(t, subtest(t, result, "Parallel").Output,
+		"parallel tests: testgroup: the tests that ran in parallel changed the process's environment")
+}
+
+func Test_GuardEnvironment_TestingSetenv(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunSerially(t, &TestingSetenvGroup{}, testgroup.GuardEnvironment())
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.Equal(t, []string{"UsesTestingSetenv"}, subtestNames(t, result, testgrouptest.Failed))
+	assert.Contains(t, subtest(t, result, "UsesTestingSetenv").Output,
+		`$TESTGROUP_TESTING_SETENV was set to "testing"`)
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "UsesTSetenv").Outcome)
+}
+
+// TestingSetenvGroup shows that GuardEnvironment flags changes made with the embedded *testing.T's
+// Setenv, which the testing package only undoes after the test is checked.
+type TestingSetenvGroup struct{}
+
+func (*TestingSetenvGroup) UsesTSetenv(t *testgroup.T) {
+	t.Setenv("TESTGROUP_TESTING_SETENV", "testgroup")
+}
+
+func (*TestingSetenvGroup) UsesTestingSetenv(t *testgroup.T) {
+	t.T.Setenv("TESTGROUP_TESTING_SETENV", "testing")
+}
+
+type GuardEnvironmentGroup struct {
+	dir string
+}
+
+func (g *GuardEnvironmentGroup) PreGroup(t *testgroup.T) {
+	t.Setenv("TESTGROUP_GROUP", "group")
+}
+
+func (g *GuardEnvironmentGroup) Serial() map[string]testgroup.SerialPhase {
+	return map[string]testgroup.SerialPhase{"VerifiesRestored": testgroup.AfterParallel}
+}
+
+func (g *GuardEnvironmentGroup) PostTest(t *testgroup.T) {
+	if strings.HasSuffix(t.Name(), "/ChangesDirectory") {
+		t.Require.NoError(os.Chdir(g.dir))
+	}
+}
+
+func (g *GuardEnvironmentGroup) ChangesDirectory(t *testgroup.T) {}
+
+func (g *GuardEnvironmentGroup) SetsVariable(t *testgroup.T) {
+	t.Require.NoError(os.Setenv("TESTGROUP_LEAK", "leak"))
+}
+
+func (g *GuardEnvironmentGroup) UsesSetenv(t *testgroup.T) {
+	t.Setenv("TESTGROUP_TEST", "test")
+}
+
+func (g *GuardEnvironmentGroup) VerifiesRestored(t *testgroup.T) {
+	_, set := os.LookupEnv("TESTGROUP_LEAK")
+	t.False(set)
+
+	dir, err := os.Getwd()
+	t.Require.NoError(err)
+	t.NotEqual(g.dir, dir)
+
+	t.Equal("group", os.Getenv("TESTGROUP_GROUP"))
+}
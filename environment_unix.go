@@ -0,0 +1,61 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package testgroup
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// currentUmask returns the process's umask. On Linux, it's read from /proc/self/status. Other
+// systems, and Linux before 4.7, have no way to read it without setting it, so it's set to 0 and
+// back, and files that other goroutines create in the meantime, like those of tests running in
+// parallel in other groups, don't have the umask applied.
+func currentUmask() int {
+	if umask, ok := procUmask(); ok {
+		return umask
+	}
+
+	umask := syscall.Umask(0)
+	syscall.Umask(umask)
+
+	return umask
+}
+
+// procUmask reads the umask from the Umask line of /proc/self/status.
+func procUmask() (int, bool) {
+	status, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return 0, false
+	}
+
+	for _, line := range strings.Split(string(status), "\n") {
+		if value := strings.TrimPrefix(line, "Umask:"); value != line {
+			umask, err := strconv.ParseInt(strings.TrimSpace(value), 8, 32)
+			return int(umask), err == nil
+		}
+	}
+
+	return 0, false
+}
+
+func setUmask(umask int) {
+	syscall.Umask(umask)
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package testgroup_test
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GuardEnvironment_Umask(t *testing.T) {
+	umask := syscall.Umask(0o022)
+	defer syscall.Umask(umask)
+
+	result := testgrouptest.RunSerially("Group", &UmaskGroup{}, testgroup.GuardEnvironment())
+
+	assert.Equal(t, []string{"Group/ChangesUmask", "Group"}, result.Names(testgrouptest.Failed))
+	assert.Contains(t, result.Test("Group/ChangesUmask").Output, "the umask was changed from 022 to 077")
+	assert.Equal(t, 0o022, syscall.Umask(0o022), "the umask should be changed back")
+}
+
+type UmaskGroup struct{}
+
+func (*UmaskGroup) ChangesUmask(t *testgroup.T) {
+	syscall.Umask(0o077)
+}
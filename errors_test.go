@@ -78,3 +78,78 @@ type GroupWithMixedReceiverMethods struct{}
 func (*GroupWithMixedReceiverMethods) PointerMethod(t *testgroup.T) {}
 
 func (GroupWithMixedReceiverMethods) NonPointerMethod(t *testgroup.T) {}
+
+//------------------------------------------------------------------------------
+
+func Test_Error_DeclarationWithBadSignature(t *testing.T) {
+	testgroup.RunSerially(t, &DeclarationWithBadSignatureGroup{})
+}
+
+type DeclarationWithBadSignatureGroup struct{}
+
+func (*DeclarationWithBadSignatureGroup) Tags() map[string]string { return nil }
+
+func (*DeclarationWithBadSignatureGroup) Test(t *testgroup.T) {}
+
+//------------------------------------------------------------------------------
+
+func Test_Error_DeclarationForMissingMethod(t *testing.T) {
+	testgroup.RunSerially(t, &DeclarationForMissingMethodGroup{})
+}
+
+type DeclarationForMissingMethodGroup struct{}
+
+func (*DeclarationForMissingMethodGroup) Tags() map[string][]string {
+	return map[string][]string{"Tset": {"typo"}}
+}
+
+func (*DeclarationForMissingMethodGroup) Test(t *testgroup.T) {}
+
+//------------------------------------------------------------------------------
+
+func Test_Error_SerialWithUnknownPhase(t *testing.T) {
+	testgroup.RunInParallel(t, &SerialWithUnknownPhaseGroup{})
+}
+
+type SerialWithUnknownPhaseGroup struct{}
+
+func (*SerialWithUnknownPhaseGroup) Serial() map[string]testgroup.SerialPhase {
+	return map[string]testgroup.SerialPhase{"Test": 0}
+}
+
+func (*SerialWithUnknownPhaseGroup) Test(t *testgroup.T) {}
+
+//------------------------------------------------------------------------------
+
+func Test_Error_DependencyCycle(t *testing.T) {
+	testgroup.RunScenario(t, &DependencyCycleGroup{})
+}
+
+type DependencyCycleGroup struct{}
+
+func (*DependencyCycleGroup) DependsOn() map[string][]string {
+	return map[string][]string{
+		"A": {"B"},
+		"B": {"C"},
+		"C": {"A"},
+	}
+}
+
+func (*DependencyCycleGroup) A(t *testgroup.T) {}
+func (*DependencyCycleGroup) B(t *testgroup.T) {}
+func (*DependencyCycleGroup) C(t *testgroup.T) {}
+
+//------------------------------------------------------------------------------
+
+func Test_Error_DependencyOnMissingMethod(t *testing.T) {
+	testgroup.RunScenario(t, &DependencyOnMissingMethodGroup{})
+}
+
+type DependencyOnMissingMethodGroup struct{}
+
+func (*DependencyOnMissingMethodGroup) DependsOn() map[string][]string {
+	return map[string][]string{"Update": {"Craete"}}
+}
+
+func (*DependencyOnMissingMethodGroup) Create(t *testgroup.T) {}
+func (*DependencyOnMissingMethodGroup) Update(t *testgroup.T) {}
@@ -0,0 +1,96 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"context"
+	"sync"
+)
+
+// FailFast stops running the group's test methods once one of them fails, so that a broken step
+// of a scenario doesn't bury its failure under the failures it causes later.
+//
+// RunSerially skips the methods after the one that failed. RunInParallel skips the methods that
+// haven't started yet, and cancels the Context of the methods that are running, which are expected
+// to stop early when it's done. A failure in PreGroup stops the group's methods too. PostGroup
+// still runs.
+func FailFast() Option {
+	return func(o *options) { o.failFast = true }
+}
+
+// failFast enforces the FailFast option.
+type failFast struct {
+	enabled bool
+
+	// ctx is the parent of the test methods' contexts. It's canceled when the group stops.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mutex        sync.Mutex
+	firstFailure string // the test method or hook that stopped the group
+}
+
+func newFailFast(t TB, enabled bool) *failFast {
+	ctx, cancel := context.WithCancel(testContext(t))
+	t.Cleanup(cancel)
+
+	return &failFast{enabled: enabled, ctx: ctx, cancel: cancel}
+}
+
+// check stops the group if the option is enabled and result has failed. phase is the hook that
+// just ran, or "" for the test method itself.
+func (f *failFast) check(result *testResult, phase string) {
+	if !f.enabled {
+		return
+	}
+
+	result.mutex.Lock()
+	failed := result.failed
+	result.mutex.Unlock()
+
+	if !failed {
+		return
+	}
+
+	name := result.name
+	if name == "" {
+		name = phase // a group hook
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.firstFailure == "" {
+		f.firstFailure = name
+		f.cancel()
+	}
+}
+
+// stopped returns what failed first if the group has stopped.
+func (f *failFast) stopped() (firstFailure string, ok bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.firstFailure, f.firstFailure != ""
+}
+
+// skipIfStopped skips a test method if the group has stopped.
+func (r *runner) skipIfStopped(t *T) {
+	t.tb.Helper()
+
+	if failure, stopped := r.failFast.stopped(); stopped {
+		t.Skipf("testgroup: skipped because %s failed and the group uses FailFast", failure)
+	}
+}
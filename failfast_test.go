@@ -0,0 +1,122 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FailFast_Serial(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunSerially(t, &FailFastSerial{}, testgroup.FailFast())
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.Equal(t, []string{"A_Passes"}, subtestNames(t, result, testgrouptest.Passed))
+	assert.Equal(t, []string{"B_Fails"}, subtestNames(t, result, testgrouptest.Failed))
+	assert.Equal(t, []string{"C_Skipped", "D_Skipped"}, subtestNames(t, result, testgrouptest.Skipped))
+	assert.Contains(t, subtest(t, result, "C_Skipped").Output,
+		"testgroup: skipped because B_Fails failed and the group uses FailFast")
+	assert.NotContains(t, result.Output, "should not run")
+}
+
+type FailFastSerial struct{}
+
+func (*FailFastSerial) PostTest(t *testgroup.T) { t.Log("PostTest ran") }
+
+func (*FailFastSerial) A_Passes(t *testgroup.T)  {}
+func (*FailFastSerial) B_Fails(t *testgroup.T)   { t.Equal(1, 2) }
+func (*FailFastSerial) C_Skipped(t *testgroup.T) { t.Fatal("should not run") }
+func (*FailFastSerial) D_Skipped(t *testgroup.T) { t.Fatal("should not run") }
+
+//------------------------------------------------------------------------------
+
+func Test_FailFast_Parallel(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunInParallel(t, &FailFastParallel{}, testgroup.FailFast())
+		return
+	}
+
+	result := testgrouptest.Rerun(t, "-test.parallel=4")
+
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "_/A_Fails").Outcome)
+	assert.Contains(t, result.Output, "context canceled")
+	assert.Contains(t, result.Output, "testgroup: skipped because A_Fails failed and the group uses FailFast")
+	assert.Equal(t, testgrouptest.Skipped, subtest(t, result, "Z_After").Outcome)
+	assert.NotContains(t, result.Output, "was not canceled")
+}
+
+// FailFastParallel has two methods that can't run at the same time, so one of them starts and is
+// canceled when A_Fails fails, and the other one is skipped.
+type FailFastParallel struct{}
+
+func (*FailFastParallel) Resources() map[string][]testgroup.Resource {
+	return map[string][]testgroup.Resource{
+		"B_Waits": {testgroup.Exclusive("server")},
+		"C_Waits": {testgroup.Exclusive("server")},
+	}
+}
+
+func (*FailFastParallel) Serial() map[string]testgroup.SerialPhase {
+	return map[string]testgroup.SerialPhase{"Z_After": testgroup.AfterParallel}
+}
+
+func (*FailFastParallel) A_Fails(t *testgroup.T) {
+	time.Sleep(50 * time.Millisecond)
+	t.Fatal("failing")
+}
+
+func (*FailFastParallel) wait(t *testgroup.T) {
+	select {
+	case <-t.Context().Done():
+		t.Log(t.Context().Err())
+	case <-time.After(10 * time.Second):
+		t.Fatal("the context was not canceled")
+	}
+}
+
+func (g *FailFastParallel) B_Waits(t *testgroup.T) { g.wait(t) }
+func (g *FailFastParallel) C_Waits(t *testgroup.T) { g.wait(t) }
+func (g *FailFastParallel) Z_After(t *testgroup.T) { t.Fatal("should not run") }
+
+//------------------------------------------------------------------------------
+
+func Test_FailFast_PreGroup(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunSerially(t, &FailFastPreGroup{}, testgroup.FailFast())
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "").Outcome)
+	assert.Equal(t, testgrouptest.Skipped, subtest(t, result, "Test").Outcome)
+	assert.Contains(t, subtest(t, result, "Test").Output,
+		"testgroup: skipped because PreGroup failed and the group uses FailFast")
+	assert.NotContains(t, result.Output, "should not run")
+}
+
+type FailFastPreGroup struct{}
+
+func (*FailFastPreGroup) PreGroup(t *testgroup.T) { t.T.Fail() }
+
+func (*FailFastPreGroup) Test(t *testgroup.T) { t.Fatal("should not run") }
@@ -0,0 +1,145 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DetectFileLeaks fails a test method if file descriptors that were opened while it and its PreTest
+// and PostTest hooks ran are still open after PostTest returns. The failure describes the leaked
+// descriptors: the paths of files, and the local addresses of TCP and UDP sockets, including
+// listeners. Descriptors that are opened by the group's other hooks and are still open after
+// PostGroup returns fail the group.
+//
+// Leaks in test methods that run in parallel fail the group instead; see RunInParallel.
+//
+// DetectFileLeaks only works on Linux, where it reads /proc/self/fd. It does nothing elsewhere.
+func DetectFileLeaks() Option {
+	return func(o *options) { o.detectFileLeaks = true }
+}
+
+// fileSnapshot is the set of file descriptors that were open when a test method or group started,
+// keyed by descriptor number and what it refers to, since numbers get reused.
+type fileSnapshot map[string]bool
+
+// fileLeaks remembers which descriptors have been reported, so that a descriptor that a test
+// method leaks isn't reported again for the group.
+type fileLeaks struct {
+	mutex    sync.Mutex
+	reported map[string]bool
+}
+
+// snapshotFiles notes which file descriptors are open, or returns nil if the group doesn't detect
+// file leaks, if they can't be detected on this system, or if the test runs in parallel with
+// others.
+func (r *runner) snapshotFiles(parallel bool) fileSnapshot {
+	if !r.opts.detectFileLeaks || parallel {
+		return nil
+	}
+
+	files, ok := openFiles()
+	if !ok {
+		return nil
+	}
+
+	s := fileSnapshot{}
+	for fd, target := range files {
+		s[fileKey(fd, target)] = true
+	}
+
+	return s
+}
+
+// checkFiles fails t if file descriptors that were opened since the snapshot are still open after
+// leakWait.
+func (r *runner) checkFiles(t *T, result *testResult, s fileSnapshot) {
+	t.tb.Helper()
+
+	if s == nil {
+		return
+	}
+
+	deadline := time.Now().Add(leakWait)
+
+	leaked := r.leakedFiles(s)
+	for len(leaked) > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+
+		leaked = r.leakedFiles(s)
+	}
+
+	if len(leaked) == 0 {
+		return
+	}
+
+	fds := make([]int, 0, len(leaked))
+	for fd := range leaked {
+		fds = append(fds, fd)
+	}
+
+	sort.Ints(fds)
+
+	lines := make([]string, len(fds))
+
+	r.fileLeaks.mutex.Lock()
+	for i, fd := range fds {
+		r.fileLeaks.reported[fileKey(fd, leaked[fd])] = true
+		lines[i] = fmt.Sprintf("fd %d: %s", fd, describeFile(leaked[fd]))
+	}
+	r.fileLeaks.mutex.Unlock()
+
+	message := fmt.Sprintf("testgroup: leaked %d file descriptor(s), which are still open:\n\n%s",
+		len(leaked), strings.Join(lines, "\n"))
+
+	r.runPhase(t.tb, result, t.slot, "file leak check", func() {
+		t.log(message)
+		t.fail(message)
+	})
+}
+
+// leakedFiles returns the file descriptors that were opened since the snapshot and haven't been
+// reported, with what they refer to.
+func (r *runner) leakedFiles(s fileSnapshot) map[int]string {
+	files, _ := openFiles()
+
+	r.fileLeaks.mutex.Lock()
+	defer r.fileLeaks.mutex.Unlock()
+
+	leaked := map[int]string{}
+
+	for fd, target := range files {
+		key := fileKey(fd, target)
+		if !s[key] && !r.fileLeaks.reported[key] && !runtimeFile(target) {
+			leaked[fd] = target
+		}
+	}
+
+	return leaked
+}
+
+func fileKey(fd int, target string) string {
+	return fmt.Sprintf("%d %s", fd, target)
+}
+
+// runtimeFile reports whether a file descriptor may belong to the Go runtime, which opens some the
+// first time a program uses the network and never closes them.
+func runtimeFile(target string) bool {
+	return target == "anon_inode:[eventpoll]" || target == "anon_inode:[eventfd]"
+}
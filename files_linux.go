@@ -0,0 +1,126 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// openFiles returns the process's open file descriptors and what they refer to, like a file's path
+// or "socket:[inode]".
+func openFiles() (map[int]string, bool) {
+	dir, err := os.Open("/proc/self/fd")
+	if err != nil {
+		return nil, false
+	}
+	defer dir.Close()
+
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return nil, false
+	}
+
+	files := map[int]string{}
+
+	for _, name := range names {
+		fd, err := strconv.Atoi(name)
+		if err != nil || uintptr(fd) == dir.Fd() {
+			continue
+		}
+
+		// The descriptor may have been closed since the directory was read.
+		if target, err := os.Readlink("/proc/self/fd/" + name); err == nil {
+			files[fd] = target
+		}
+	}
+
+	return files, true
+}
+
+// describeFile adds the protocol, local address, and state of TCP and UDP sockets to what a file
+// descriptor refers to.
+func describeFile(target string) string {
+	if !strings.HasPrefix(target, "socket:[") {
+		return target
+	}
+
+	inode := strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]")
+
+	for _, protocol := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		if socket := findSocket(protocol, inode); socket != "" {
+			return target + " (" + socket + ")"
+		}
+	}
+
+	return target
+}
+
+// findSocket looks for a socket in /proc/net/<protocol>, where each line looks like this:
+//
+//	sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+//	 0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 12345
+func findSocket(protocol, inode string) string {
+	b, err := os.ReadFile("/proc/net/" + protocol)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(b), "\n")[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 10 || fields[9] != inode {
+			continue
+		}
+
+		description := strings.TrimSuffix(protocol, "6") + " " + parseSocketAddress(fields[1])
+
+		if strings.HasPrefix(protocol, "tcp") && fields[3] == "0A" {
+			description += ", listening"
+		}
+
+		return description
+	}
+
+	return ""
+}
+
+// parseSocketAddress parses an address from /proc/net, where the IP address is written as 32-bit
+// words in the host's byte order, which is assumed to be little endian.
+func parseSocketAddress(s string) string {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return s
+	}
+
+	ip, err := hex.DecodeString(parts[0])
+	if err != nil || len(ip)%4 != 0 {
+		return s
+	}
+
+	for i := 0; i < len(ip); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = ip[i+3], ip[i+2], ip[i+1], ip[i]
+	}
+
+	port, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return s
+	}
+
+	return net.JoinHostPort(net.IP(ip).String(), fmt.Sprint(port))
+}
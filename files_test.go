@@ -0,0 +1,102 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DetectFileLeaks(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("DetectFileLeaks only works on Linux")
+	}
+
+	if testgrouptest.InSubprocess() {
+		dir := t.TempDir()
+
+		t.Run("Serial", func(t *testing.T) {
+			testgroup.RunSerially(t, &FileLeakGroup{dir: dir}, testgroup.DetectFileLeaks())
+		})
+		t.Run("Parallel", func(t *testing.T) {
+			testgroup.RunInParallel(t, &FileLeakGroup{dir: dir}, testgroup.DetectFileLeaks())
+		})
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "Serial/LeaksFile").Outcome)
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "Serial/LeaksListener").Outcome)
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Serial/ClosesFile").Outcome)
+
+	// Parallel methods aren't checked one by one.
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Parallel/_/LeaksFile").Outcome)
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Parallel/_/LeaksListener").Outcome)
+
+	serial := subtest(t, result, "Serial").Output
+	assert.Contains(t, serial, "testgroup: 2 of 3 tests failed in Test_DetectFileLeaks/Serial")
+	assert.Contains(t, serial, "file leak check: testgroup: leaked 1 file descriptor(s), which are still open:")
+	assert.Regexp(t, regexp.MustCompile(`fd \d+: .*/pre-group\n`), serial)
+
+	parallel := subtest(t, result, "Parallel").Output
+	assert.Contains(t, parallel, "testgroup: 0 of 3 tests failed in Test_DetectFileLeaks/Parallel")
+	assert.Contains(t, parallel, "file leak check: testgroup: leaked 3 file descriptor(s), which are still open:")
+
+	leaksFile := subtest(t, result, "Serial/LeaksFile").Output
+	assert.Regexp(t, regexp.MustCompile(`fd \d+: .*/leaked\n`), leaksFile)
+
+	leaksListener := subtest(t, result, "Serial/LeaksListener").Output
+	assert.Regexp(t, regexp.MustCompile(`fd \d+: socket:\[\d+\] \(tcp 127\.0\.0\.1:\d+, listening\)`), leaksListener)
+
+	assert.NotContains(t, result.Output, "/closed\n")
+}
+
+type FileLeakGroup struct {
+	dir string
+}
+
+func (g *FileLeakGroup) PreGroup(t *testgroup.T) {
+	g.create(t, "pre-group")
+}
+
+func (g *FileLeakGroup) LeaksFile(t *testgroup.T) {
+	g.create(t, "leaked")
+}
+
+func (g *FileLeakGroup) LeaksListener(t *testgroup.T) {
+	_, err := net.Listen("tcp", "127.0.0.1:0")
+	t.Require.NoError(err)
+}
+
+func (g *FileLeakGroup) ClosesFile(t *testgroup.T) {
+	t.NoError(g.create(t, "closed").Close())
+}
+
+func (g *FileLeakGroup) create(t *testgroup.T, name string) *os.File {
+	f, err := os.Create(filepath.Join(g.dir, name))
+	t.Require.NoError(err)
+
+	return f
+}
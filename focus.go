@@ -0,0 +1,122 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FocusPrefix marks a test method as focused. For example, renaming a method from CreateTable to
+// FOCUS_CreateTable focuses it. It's loud so that it doesn't match the names of existing methods.
+const FocusPrefix = "FOCUS_"
+
+// Focus runs only the given test methods of the group, which is handy while working on them. The
+// group's other methods are skipped. Methods whose names start with FocusPrefix are focused too.
+//
+// Focusing is only meant for local development. When the CI environment variable is set, as it is
+// by most continuous integration services, a group with focused methods fails and runs all of its
+// methods, so that focused methods don't get merged.
+func Focus(methods ...string) Option {
+	return func(o *options) { o.focus = append(o.focus, methods...) }
+}
+
+// findFocus returns the names of the focused test methods of the group, or nil if no methods are
+// focused.
+func (r *runner) findFocus(t TB) map[string]bool {
+	t.Helper()
+
+	isMethod := map[string]bool{}
+	for _, m := range r.methods {
+		isMethod[m.Name] = true
+	}
+
+	focused := map[string]bool{}
+
+	for _, name := range r.opts.focus {
+		if !isMethod[name] {
+			t.Fatalf("testgroup: the Focus option mentions %q, which is not a test method of %T.", name, r.group)
+		}
+
+		focused[name] = true
+	}
+
+	for _, m := range r.methods {
+		if strings.HasPrefix(m.Name, FocusPrefix) {
+			focused[m.Name] = true
+		}
+	}
+
+	if len(focused) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(focused))
+	for name := range focused {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	if inCI() {
+		t.Errorf("testgroup: %T has focused tests (%s), which are only for local development."+
+			" Remove the focus before merging.", r.group, strings.Join(names, ", "))
+
+		return nil
+	}
+
+	t.Logf("testgroup: only running the focused tests: %s", strings.Join(names, ", "))
+
+	if r.scenario {
+		// A scenario's focused steps can't run without the steps they depend on.
+		for _, name := range names {
+			focusDependencies(name, r.decls.dependsOn, focused)
+		}
+	}
+
+	return focused
+}
+
+func focusDependencies(step string, dependsOn map[string][]string, focused map[string]bool) {
+	for _, dependency := range dependsOn[step] {
+		if !focused[dependency] {
+			focused[dependency] = true
+			focusDependencies(dependency, dependsOn, focused)
+		}
+	}
+}
+
+// inCI reports whether the tests seem to be running in a continuous integration service.
+func inCI() bool {
+	value := os.Getenv("CI")
+	if value == "" {
+		return false
+	}
+
+	ci, err := strconv.ParseBool(value)
+
+	return err != nil || ci
+}
+
+// skipIfUnfocused skips a test method if other methods of the group are focused.
+func (r *runner) skipIfUnfocused(t *T, method string) {
+	t.tb.Helper()
+
+	if r.focused != nil && !r.focused[method] {
+		t.Skip("testgroup: skipped because other tests in the group are focused")
+	}
+}
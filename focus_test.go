@@ -0,0 +1,88 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Focus(t *testing.T) {
+	t.Setenv("CI", "")
+
+	t.Run("Prefix", func(t *testing.T) {
+		g := &Focus{}
+		testgroup.RunInParallel(t, g)
+		assert.Equal(t, []string{"FOCUS_B"}, g.ran, "only the prefix FOCUS_ should focus tests")
+	})
+
+	t.Run("Option", func(t *testing.T) {
+		g := &Focus{}
+		testgroup.RunSerially(t, g, testgroup.Focus("A", "F_C"))
+		assert.Equal(t, []string{"A", "FOCUS_B", "F_C"}, g.ran)
+	})
+
+	t.Run("Scenario", func(t *testing.T) {
+		g := &FocusScenario{}
+		testgroup.RunScenario(t, g, testgroup.Focus("Delete"))
+		assert.Equal(t, []string{"Create", "Delete"}, g.ran)
+	})
+}
+
+type Focus struct {
+	ran []string
+}
+
+func (g *Focus) A(t *testgroup.T)       { g.ran = append(g.ran, "A") }
+func (g *Focus) FOCUS_B(t *testgroup.T) { g.ran = append(g.ran, "FOCUS_B") }
+func (g *Focus) F_C(t *testgroup.T)     { g.ran = append(g.ran, "F_C") }
+func (g *Focus) D(t *testgroup.T)       { g.ran = append(g.ran, "D") }
+
+type FocusScenario struct {
+	ran []string
+}
+
+func (*FocusScenario) DependsOn() map[string][]string {
+	return map[string][]string{"Delete": {"Create"}}
+}
+
+func (g *FocusScenario) Create(t *testgroup.T) { g.ran = append(g.ran, "Create") }
+func (g *FocusScenario) Delete(t *testgroup.T) { g.ran = append(g.ran, "Delete") }
+func (g *FocusScenario) List(t *testgroup.T)   { g.ran = append(g.ran, "List") }
+
+//------------------------------------------------------------------------------
+
+func Test_Focus_InCI(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		t.Setenv("CI", "true")
+
+		g := &Focus{}
+		testgroup.RunSerially(t, g)
+		assert.Equal(t, []string{"A", "D", "FOCUS_B", "F_C"}, g.ran, "all tests should run in CI")
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.Empty(t, subtestNames(t, result, testgrouptest.Failed), "only the group should fail")
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "").Outcome)
+	assert.Contains(t, subtest(t, result, "").Output, "testgroup: *testgroup_test.Focus has focused tests (FOCUS_B),"+
+		" which are only for local development. Remove the focus before merging.")
+	assert.NotContains(t, result.Output, "Error Trace", "all tests should run in CI")
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A Global is a piece of global state, like a package's default client, a feature flag, or a
+// registry, that tests might change. The GuardGlobals and RestoreGlobals options save it before
+// each test method and check or restore it afterward.
+type Global struct {
+	// Name identifies the state in failure messages.
+	Name string
+	// Get returns the current value of the state.
+	Get func() interface{}
+	// Set changes the state back to a value that Get returned. It's nil if the state can't be
+	// changed back.
+	Set func(value interface{})
+
+	identity bool // compare pointers, maps, slices, and channels by identity, for GlobalVar
+}
+
+// GlobalVar returns a Global for a global variable, given a pointer to it:
+//
+//	testgroup.GlobalVar("http.DefaultClient", &http.DefaultClient)
+//
+// Only assignments to the variable are noticed, including assignments of a new pointer to an equal
+// value. For example, adding to a global map doesn't change the variable. Use GlobalFunc with a Get
+// function that returns a copy to watch a map's contents.
+func GlobalVar(name string, ptr interface{}) Global {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic(fmt.Sprintf("testgroup: GlobalVar needs a pointer to a variable, not %T", ptr))
+	}
+
+	variable := v.Elem()
+
+	return Global{
+		Name:     name,
+		identity: true,
+		Get:      func() interface{} { return variable.Interface() },
+		Set: func(value interface{}) {
+			if value == nil {
+				variable.Set(reflect.Zero(variable.Type()))
+			} else {
+				variable.Set(reflect.ValueOf(value))
+			}
+		},
+	}
+}
+
+// GlobalFunc returns a Global for state that is read and changed with functions, like a feature
+// flag with a getter and a setter. set may be nil if the state can't be changed back. The values
+// that get returns are compared by their contents, so get can return a copy of the state.
+func GlobalFunc(name string, get func() interface{}, set func(value interface{})) Global {
+	return Global{Name: name, Get: get, Set: set}
+}
+
+// GuardGlobals fails a test method if it, or its PreTest or PostTest hooks, changed any of the
+// globals without changing them back, and changes them back so that the group's later methods
+// aren't affected. The failure says which globals changed, and how. Globals that PreGroup or
+// PostGroup change without changing back fail the group.
+//
+// Globals changed by test methods that run in parallel fail the group instead; see RunInParallel.
+func GuardGlobals(globals ...Global) Option {
+	return func(o *options) {
+		for _, g := range globals {
+			o.globals = append(o.globals, guardedGlobal{Global: g})
+		}
+	}
+}
+
+// RestoreGlobals is like GuardGlobals, but it only changes the globals back, without failing the
+// tests that changed them. It's useful for state that tests are meant to change, like feature
+// flags.
+func RestoreGlobals(globals ...Global) Option {
+	return func(o *options) {
+		for _, g := range globals {
+			o.globals = append(o.globals, guardedGlobal{Global: g, restoreOnly: true})
+		}
+	}
+}
+
+type guardedGlobal struct {
+	Global
+	restoreOnly bool
+}
+
+// globalSnapshot holds the values of the group's globals, in the order of the options.
+type globalSnapshot []interface{}
+
+// snapshotGlobals saves the values of the group's globals, or returns nil if there are none or if
+// the test runs in parallel with others.
+func (r *runner) snapshotGlobals(parallel bool) globalSnapshot {
+	if len(r.opts.globals) == 0 || parallel {
+		return nil
+	}
+
+	s := make(globalSnapshot, len(r.opts.globals))
+	for i, g := range r.opts.globals {
+		s[i] = g.Get()
+	}
+
+	return s
+}
+
+// checkGlobals changes the globals that changed since the snapshot back, and fails t if any of them
+// are guarded.
+func (r *runner) checkGlobals(t *T, result *testResult, s globalSnapshot) {
+	t.tb.Helper()
+
+	if s == nil {
+		return
+	}
+
+	var changes []string
+
+	for i, g := range r.opts.globals {
+		before, now := s[i], g.Get()
+		if sameValue(before, now, g.identity) {
+			continue
+		}
+
+		change := fmt.Sprintf("%s was changed from %#v to %#v", g.Name, before, now)
+		if fmt.Sprintf("%#v", before) == fmt.Sprintf("%#v", now) {
+			change = fmt.Sprintf("%s was changed to a different %T with the same contents: %#v", g.Name, now, now)
+		}
+
+		if g.Set == nil {
+			change += ", and testgroup can't change it back"
+		} else {
+			g.Set(before)
+		}
+
+		if !g.restoreOnly {
+			changes = append(changes, change)
+		}
+	}
+
+	if len(changes) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("testgroup: changed global state without changing it back:\n\n%s",
+		strings.Join(changes, "\n"))
+
+	r.runPhase(t.tb, result, t.slot, "global state check", func() {
+		t.log(message)
+		t.fail(message)
+	})
+}
+
+// sameValue reports whether two values of a global are the same. With identity, pointers, maps,
+// slices, and channels are the same if they refer to the same memory, like == compares pointers.
+// Without it, they're compared by their contents, like reflect.DeepEqual compares them. Either way,
+// functions are the same if they have the same code, since reflect.DeepEqual says that functions
+// are never equal, so a struct with a function field would never be the same as itself.
+func sameValue(a, b interface{}, identity bool) bool {
+	c := &valueComparison{identity: identity, visited: map[[2]uintptr]bool{}}
+	return c.same(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+type valueComparison struct {
+	identity bool
+	visited  map[[2]uintptr]bool // the pairs of references being compared, to stop at cycles
+}
+
+func (c *valueComparison) same(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Func:
+		return a.Pointer() == b.Pointer()
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.UnsafePointer:
+		return c.sameReference(a, b)
+	case reflect.Interface:
+		return c.same(a.Elem(), b.Elem())
+	case reflect.Struct:
+		return c.sameFields(a, b)
+	case reflect.Array:
+		return c.sameElements(a, b)
+	default:
+		return sameScalar(a, b)
+	}
+}
+
+func (c *valueComparison) sameReference(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Slice && a.Len() != b.Len() {
+		return false
+	}
+
+	if a.Pointer() == b.Pointer() {
+		return true
+	}
+
+	if c.identity || a.IsNil() || b.IsNil() || a.Kind() == reflect.Chan || a.Kind() == reflect.UnsafePointer {
+		return false
+	}
+
+	key := [2]uintptr{a.Pointer(), b.Pointer()}
+	if c.visited[key] {
+		return true
+	}
+
+	c.visited[key] = true
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		return c.same(a.Elem(), b.Elem())
+	case reflect.Map:
+		return c.sameEntries(a, b)
+	default:
+		return c.sameElements(a, b)
+	}
+}
+
+func (c *valueComparison) sameFields(a, b reflect.Value) bool {
+	for i := 0; i < a.NumField(); i++ {
+		if !c.same(a.Field(i), b.Field(i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *valueComparison) sameElements(a, b reflect.Value) bool {
+	for i := 0; i < a.Len(); i++ {
+		if !c.same(a.Index(i), b.Index(i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *valueComparison) sameEntries(a, b reflect.Value) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+
+	iter := a.MapRange()
+	for iter.Next() {
+		if !c.same(iter.Value(), b.MapIndex(iter.Key())) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameScalar(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+//nolint:gochecknoglobals // These are the globals that the tests change.
+var (
+	globalFlag     = "default"
+	globalNow      = time.Now
+	globalRegistry = map[string]int{}
+	globalConfig   = GlobalConfig{Name: "default", OnChange: func() {}}
+	globalClient   = &GlobalConfig{Name: "client"}
+)
+
+// GlobalConfig has a function field, so reflect.DeepEqual says that it isn't equal to itself.
+type GlobalConfig struct {
+	Name     string
+	OnChange func()
+}
+
+func globalOptions() []testgroup.Option {
+	registry := testgroup.GlobalFunc("registry",
+		func() interface{} {
+			c := map[string]int{}
+			for k, v := range globalRegistry {
+				c[k] = v
+			}
+
+			return c
+		},
+		func(value interface{}) { globalRegistry = value.(map[string]int) })
+
+	return []testgroup.Option{
+		testgroup.GuardGlobals(testgroup.GlobalVar("globalFlag", &globalFlag), registry),
+		testgroup.RestoreGlobals(testgroup.GlobalVar("globalNow", &globalNow)),
+	}
+}
+
+func Test_GuardGlobals(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		t.Run("Serial", func(t *testing.T) { testgroup.RunSerially(t, &GlobalsGroup{}, globalOptions()...) })
+		t.Run("Parallel", func(t *testing.T) { testgroup.RunInParallel(t, &GlobalsGroup{}, globalOptions()...) })
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "Serial/ChangesFlag").Outcome)
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "Serial/ChangesRegistry").Outcome)
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Serial/ChangesNow").Outcome)
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Serial/VerifiesRestored").Outcome)
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Parallel/_/ChangesFlag").Outcome)
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Parallel/VerifiesRestored").Outcome)
+
+	changesFlag := subtest(t, result, "Serial/ChangesFlag").Output
+	assert.Contains(t, changesFlag, "testgroup: changed global state without changing it back:")
+	assert.Contains(t, changesFlag, `globalFlag was changed from "default" to "changed"`)
+	assert.Contains(t, subtest(t, result, "Serial/ChangesRegistry").Output,
+		`registry was changed from map[string]int{} to map[string]int{"added":1}`)
+
+	serial := subtest(t, result, "Serial").Output
+	assert.Contains(t, serial, "testgroup: 2 of 4 tests failed in Test_GuardGlobals/Serial")
+	assert.Contains(t, serial, "ChangesFlag (in global state check): testgroup: changed global state")
+
+	parallel := subtest(t, result, "Parallel").Output
+	assert.Contains(t, parallel, "testgroup: 0 of 4 tests failed in Test_GuardGlobals/Parallel")
+	assert.Contains(t, parallel, "global state check: testgroup: changed global state")
+
+	assert.NotContains(t, result.Output, "globalNow was changed")
+}
+
+type GlobalsGroup struct{}
+
+func (*GlobalsGroup) Serial() map[string]testgroup.SerialPhase {
+	return map[string]testgroup.SerialPhase{"VerifiesRestored": testgroup.AfterParallel}
+}
+
+func (*GlobalsGroup) ChangesFlag(t *testgroup.T) {
+	globalFlag = "changed"
+}
+
+func (*GlobalsGroup) ChangesNow(t *testgroup.T) {
+	globalNow = func() time.Time { return time.Time{} }
+}
+
+func (*GlobalsGroup) ChangesRegistry(t *testgroup.T) {
+	globalRegistry["added"] = 1
+}
+
+func (*GlobalsGroup) VerifiesRestored(t *testgroup.T) {
+	t.Equal("default", globalFlag)
+	t.Empty(globalRegistry)
+	t.False(globalNow().IsZero())
+}
+
+func Test_RestoreGlobals(t *testing.T) {
+	testgroup.RunSerially(t, &RestoreGlobalsGroup{},
+		testgroup.RestoreGlobals(testgroup.GlobalVar("globalFlag", &globalFlag)))
+
+	assert.Equal(t, "default", globalFlag)
+}
+
+type RestoreGlobalsGroup struct{}
+
+func (*RestoreGlobalsGroup) A_ChangesFlag(t *testgroup.T) {
+	globalFlag = "changed"
+}
+
+func (*RestoreGlobalsGroup) B_SeesDefault(t *testgroup.T) {
+	t.Equal("default", globalFlag)
+}
+
+func Test_GuardGlobals_Identity(t *testing.T) {
+	client := globalClient
+
+	for _, global := range []testgroup.Global{
+		testgroup.GlobalVar("globalConfig", &globalConfig),
+		testgroup.GlobalFunc("globalConfig",
+			func() interface{} { return globalConfig },
+			func(value interface{}) { globalConfig = value.(GlobalConfig) }),
+	} {
+		result := testgrouptest.RunSerially("Group", &IdentityGroup{}, testgroup.GuardGlobals(global,
+			testgroup.GlobalVar("globalClient", &globalClient)))
+
+		assert.Equal(t, []string{"Group/ReadsConfig"}, result.Names(testgrouptest.Passed))
+		assert.Equal(t, []string{"Group/ReplacesClient", "Group"}, result.Names(testgrouptest.Failed))
+		assert.Contains(t, result.Test("Group/ReplacesClient").Output,
+			`globalClient was changed to a different *testgroup_test.GlobalConfig with the same contents: `+
+				`&testgroup_test.GlobalConfig{Name:"client", OnChange:(func())(nil)}`)
+		assert.Same(t, client, globalClient, "globalClient should be changed back")
+	}
+}
+
+type IdentityGroup struct{}
+
+func (*IdentityGroup) ReadsConfig(t *testgroup.T) {
+	t.Equal("default", globalConfig.Name)
+}
+
+func (*IdentityGroup) ReplacesClient(t *testgroup.T) {
+	globalClient = &GlobalConfig{Name: "client"}
+}
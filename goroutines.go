@@ -0,0 +1,288 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// leakWait is how long to wait for goroutines and files that are still being cleaned up before
+// calling them leaks.
+const leakWait = time.Second
+
+// DetectGoroutineLeaks fails a test method if goroutines that it started, or that its PreTest and
+// PostTest hooks started, are still running after PostTest returns. The failure shows the stacks of
+// the leaked goroutines. Goroutines started by PreGroup, PostGroup, or any other part of the group
+// that are still running after PostGroup returns fail the group.
+//
+// Goroutines get a moment to finish before they count as leaks. Goroutines whose stacks mention any
+// of the ignore strings never do, which is useful for background goroutines that a library starts
+// once and never stops, like "go.opencensus.io/stats/view.(*worker).start".
+//
+// In RunInParallel groups, goroutines are blamed on the test method whose goroutines started them,
+// directly or through other goroutines that are still running, so a goroutine that is started by a
+// goroutine that has already exited isn't noticed.
+func DetectGoroutineLeaks(ignore ...string) Option {
+	return func(o *options) {
+		o.detectGoroutineLeaks = true
+		o.ignoredGoroutines = append(o.ignoredGoroutines, ignore...)
+	}
+}
+
+// A goroutine is an entry in the output of runtime.Stack.
+type goroutine struct {
+	id      uint64
+	creator uint64 // 0 if unknown
+	stack   string
+}
+
+// goroutineSnapshot is the set of goroutines that were running when a test method or group started.
+type goroutineSnapshot struct {
+	before map[uint64]bool
+
+	mutex  sync.Mutex
+	owners map[uint64]bool // the goroutines that testgroup started to run the test
+}
+
+// own notes that the current goroutine runs part of the test, like a subtest, so that goroutines
+// that it starts are blamed on the test even after it exits.
+func (s *goroutineSnapshot) own() {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.owners[goroutineID()] = true
+}
+
+// goroutineLeaks remembers which goroutines have been reported, so that a goroutine that a test
+// method leaks isn't reported again for the group.
+type goroutineLeaks struct {
+	mutex    sync.Mutex
+	reported map[uint64]bool
+}
+
+// snapshotGoroutines notes which goroutines are running, or returns nil if the group doesn't
+// detect goroutine leaks.
+func (r *runner) snapshotGoroutines() *goroutineSnapshot {
+	if !r.opts.detectGoroutineLeaks {
+		return nil
+	}
+
+	s := &goroutineSnapshot{before: map[uint64]bool{}, owners: map[uint64]bool{goroutineID(): true}}
+	for id := range allGoroutines() {
+		s.before[id] = true
+	}
+
+	return s
+}
+
+// checkGoroutines fails t if goroutines that it started since the snapshot are still running after
+// leakWait.
+func (r *runner) checkGoroutines(t *T, result *testResult, s *goroutineSnapshot) {
+	t.tb.Helper()
+
+	if s == nil {
+		return
+	}
+
+	deadline := time.Now().Add(leakWait)
+
+	leaked := r.leakedGoroutines(s)
+	for len(leaked) > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+
+		leaked = r.leakedGoroutines(s)
+	}
+
+	if len(leaked) == 0 {
+		return
+	}
+
+	r.goroutineLeaks.mutex.Lock()
+	for _, g := range leaked {
+		r.goroutineLeaks.reported[g.id] = true
+	}
+	r.goroutineLeaks.mutex.Unlock()
+
+	stacks := make([]string, len(leaked))
+	for i, g := range leaked {
+		stacks[i] = g.stack
+	}
+
+	message := fmt.Sprintf("testgroup: leaked %d goroutine(s), which are still running:\n\n%s",
+		len(leaked), strings.Join(stacks, "\n\n"))
+
+	r.runPhase(t.tb, result, t.slot, "goroutine leak check", func() {
+		t.log(message)
+		t.fail(message)
+	})
+}
+
+// leakedGoroutines returns the goroutines that were started by the snapshot's owners since the
+// snapshot and haven't been reported or ignored.
+func (r *runner) leakedGoroutines(s *goroutineSnapshot) []goroutine {
+	running := allGoroutines()
+
+	owners := map[uint64]bool{}
+
+	s.mutex.Lock()
+	for id := range s.owners {
+		owners[id] = true
+	}
+	s.mutex.Unlock()
+
+	leaked := map[uint64]goroutine{}
+
+	// Follow the chains of goroutines that started goroutines, starting from the owners.
+	for changed := true; changed; {
+		changed = false
+
+		for id, g := range running {
+			if s.before[id] || owners[id] || !r.startedBy(g, owners, running, s) {
+				continue
+			}
+
+			owners[id] = true
+			changed = true
+
+			if !r.ignoredGoroutine(g) {
+				leaked[id] = g
+			}
+		}
+	}
+
+	ids := make([]uint64, 0, len(leaked))
+	for id := range leaked {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	result := make([]goroutine, len(ids))
+	for i, id := range ids {
+		result[i] = leaked[id]
+	}
+
+	return result
+}
+
+// startedBy reports whether a goroutine was started by one of owners. When that can't be told,
+// because the runtime doesn't say or because the goroutine that started it has exited, it's blamed
+// on the owners unless other tests of the group could have started it.
+func (r *runner) startedBy(
+	g goroutine, owners map[uint64]bool, running map[uint64]goroutine, s *goroutineSnapshot,
+) bool {
+	switch {
+	case g.creator == 0:
+		return true
+	case owners[g.creator]:
+		return true
+	}
+
+	_, creatorRunning := running[g.creator]
+
+	return !r.parallel && !creatorRunning && !s.before[g.creator]
+}
+
+func (r *runner) ignoredGoroutine(g goroutine) bool {
+	// A test method's parallel subtests wait in T.Parallel until the method returns.
+	if strings.Contains(g.stack, "\ntesting.(*T).Parallel(") {
+		return true
+	}
+
+	r.goroutineLeaks.mutex.Lock()
+	reported := r.goroutineLeaks.reported[g.id]
+	r.goroutineLeaks.mutex.Unlock()
+
+	if reported {
+		return true
+	}
+
+	for _, s := range r.opts.ignoredGoroutines {
+		if strings.Contains(g.stack, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allGoroutines returns the goroutines that are running, by ID.
+func allGoroutines() map[uint64]goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
+
+	goroutines := map[uint64]goroutine{}
+
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		if g, ok := parseGoroutine(string(stack)); ok {
+			goroutines[g.id] = g
+		}
+	}
+
+	return goroutines
+}
+
+// parseGoroutine parses a stack from runtime.Stack, which looks like this:
+//
+//	goroutine 7 [chan receive]:
+//	example.com/pkg.worker(...)
+//		/src/pkg/worker.go:12 +0x25
+//	created by example.com/pkg.Start in goroutine 6
+//		/src/pkg/worker.go:7 +0x3c
+func parseGoroutine(stack string) (goroutine, bool) {
+	stack = strings.TrimSpace(stack)
+
+	var g goroutine
+
+	header := strings.SplitN(stack, "\n", 2)[0]
+	if !strings.HasPrefix(header, "goroutine ") {
+		return g, false
+	}
+
+	fields := strings.Fields(header)
+
+	id, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return g, false
+	}
+
+	g.id, g.stack = id, stack
+
+	if i := strings.LastIndex(stack, " in goroutine "); i >= 0 {
+		line := strings.SplitN(stack[i+len(" in goroutine "):], "\n", 2)[0]
+		g.creator, _ = strconv.ParseUint(line, 10, 64)
+	}
+
+	return g, true
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DetectGoroutineLeaks(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		opts := []testgroup.Option{testgroup.DetectGoroutineLeaks("testgroup_test.ignoredGoroutine")}
+
+		t.Run("Serial", func(t *testing.T) { testgroup.RunSerially(t, &GoroutineLeakGroup{}, opts...) })
+		t.Run("Parallel", func(t *testing.T) { testgroup.RunInParallel(t, &GoroutineLeakGroup{}, opts...) })
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	for _, mode := range []string{"Serial/", "Parallel/_/"} {
+		assert.Equal(t, testgrouptest.Failed, subtest(t, result, mode+"Leaks").Outcome)
+		assert.Equal(t, testgrouptest.Failed, subtest(t, result, mode+"LeaksInPostTest").Outcome)
+		assert.Equal(t, testgrouptest.Passed, subtest(t, result, mode+"FinishesSoon").Outcome)
+		assert.Equal(t, testgrouptest.Passed, subtest(t, result, mode+"LeaksIgnoredGoroutine").Outcome)
+		assert.Equal(t, testgrouptest.Failed, subtest(t, result, mode+"LeaksInSubtest").Outcome)
+
+		leaks := subtest(t, result, mode+"Leaks").Output
+		assert.Contains(t, leaks, "testgroup: leaked 1 goroutine(s), which are still running:")
+		assert.Contains(t, leaks, "testgroup_test.leakedGoroutine(")
+	}
+
+	assert.NotContains(t, result.Output, "testgroup_test.ignoredGoroutine(")
+
+	for _, group := range []string{"Serial", "Parallel"} {
+		summary := subtest(t, result, group).Output
+		assert.Contains(t, summary, "testgroup: 3 of 5 tests failed in Test_DetectGoroutineLeaks/"+group)
+		assert.Contains(t, summary, "goroutine leak check: testgroup: leaked 1 goroutine(s)")
+		assert.Contains(t, summary, "Leaks (in goroutine leak check): testgroup: leaked 1 goroutine(s)")
+		assert.Contains(t, summary, "LeaksInSubtest (in goroutine leak check): testgroup: leaked 1 goroutine(s)")
+	}
+}
+
+type GoroutineLeakGroup struct{}
+
+func (*GoroutineLeakGroup) PreGroup(t *testgroup.T) {
+	go leakedGoroutine()
+}
+
+func (*GoroutineLeakGroup) Leaks(t *testgroup.T) {
+	go leakedGoroutine()
+}
+
+func (*GoroutineLeakGroup) LeaksInPostTest(t *testgroup.T) {}
+
+func (*GoroutineLeakGroup) PostTest(t *testgroup.T) {
+	if strings.HasSuffix(t.Name(), "/LeaksInPostTest") {
+		go leakedGoroutine()
+	}
+}
+
+func (*GoroutineLeakGroup) LeaksInSubtest(t *testgroup.T) {
+	t.Run("Subtest", func(t *testgroup.T) {
+		go leakedGoroutine()
+	})
+}
+
+func (*GoroutineLeakGroup) FinishesSoon(t *testgroup.T) {
+	go time.Sleep(50 * time.Millisecond)
+}
+
+func (*GoroutineLeakGroup) LeaksIgnoredGoroutine(t *testgroup.T) {
+	go ignoredGoroutine()
+}
+
+func leakedGoroutine() { select {} }
+
+func ignoredGoroutine() { select {} }
@@ -0,0 +1,138 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// interception collects the failures and skips of a test that testgroup intercepts instead of
+// reporting them to the testing package, since the testing package can't take a failure back.
+// For example, a failed attempt of a retried method must not fail the method.
+//
+// Failures are only intercepted when they're reported through T's own methods, like its
+// assertions, Fatal, and Skip, and not through the embedded *testing.T.
+type interception struct {
+	mutex      sync.Mutex
+	failed     bool
+	message    string // the first failure message
+	skipped    bool
+	skipReason string
+}
+
+func (i *interception) fail(message string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if !i.failed {
+		i.failed = true
+		i.message = message
+	}
+}
+
+func (i *interception) skip(reason string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if !i.skipped {
+		i.skipped = true
+		i.skipReason = strings.TrimSpace(reason)
+	}
+}
+
+// outcome returns what happened to the intercepted test.
+func (i *interception) outcome() (failed bool, message string, skipped bool, skipReason string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.failed, i.message, i.skipped, i.skipReason
+}
+
+// intercept runs f with t's failures and skips intercepted, and returns what happened. f runs in
+// its own goroutine, so that T.FailNow and T.SkipNow can stop it with runtime.Goexit without the
+// testing package noticing.
+func (t *T) intercept(f func()) *interception {
+	i := &interception{}
+
+	outer := t.interception
+	t.interception = i
+
+	defer func() { t.interception = outer }()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		t.goroutines.own()
+
+		defer func() {
+			if v := recover(); v != nil {
+				message := fmt.Sprintf("panic: %v\n%s", v, debug.Stack())
+				t.log(message)
+				i.fail(message)
+			}
+		}()
+
+		f()
+	}()
+
+	<-done
+
+	return i
+}
+
+// fail marks a test as failed. The failure has already been logged.
+func (t *T) fail(message string) {
+	t.tb.Helper()
+
+	if t.interception != nil {
+		t.interception.fail(message)
+		return
+	}
+
+	t.result.recordFailure(message)
+	t.tb.Fail()
+}
+
+// failNow stops a test after a failure, which has already been logged.
+func (t *T) failNow(message string) {
+	t.tb.Helper()
+
+	if t.interception != nil {
+		t.interception.fail(message)
+		runtime.Goexit()
+	}
+
+	t.result.recordFailure(message)
+	t.tb.FailNow()
+}
+
+// skipNow stops a test and marks it as skipped. The reason has already been logged.
+func (t *T) skipNow(reason string) {
+	t.tb.Helper()
+
+	if t.interception != nil {
+		t.interception.skip(reason)
+		runtime.Goexit()
+	}
+
+	t.result.recordSkip(reason)
+	t.tb.SkipNow()
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rerun builds the arguments for running one test again, in a new test process or with go
+// test.
+package rerun
+
+import (
+	"flag"
+	"regexp"
+	"strings"
+)
+
+// Pattern returns a -test.run pattern that matches only the test with the given full name, like
+// "^TestGroup$/^Method$".
+func Pattern(name string) string {
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(part) + "$"
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// passedFlags are the flags of the current test process that Args passes on to the new one.
+// -test.gocoverdir is where go test 1.20 and later collect coverage data, so the new process's
+// coverage counts are merged into the current process's -test.coverprofile when it exits.
+// -test.coverprofile itself isn't passed on, since the new process would overwrite the file, and
+// other flags, like -test.failfast, don't matter when only one test runs.
+//
+//nolint:gochecknoglobals // This is a constant list.
+var passedFlags = []string{"test.short", "test.timeout", "test.gocoverdir"}
+
+// Args returns the arguments for running only the test with the given full name in a new process
+// of the current test binary, verbosely, once, and without caching. See passedFlags for the flags
+// of the current process that are passed on.
+func Args(name string) []string {
+	args := []string{"-test.run", Pattern(name), "-test.v", "-test.count=1"}
+
+	for _, name := range passedFlags {
+		if f := flag.Lookup(name); f != nil && f.Value.String() != "" {
+			args = append(args, "-"+name+"="+f.Value.String())
+		}
+	}
+
+	return args
+}
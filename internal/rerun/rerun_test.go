@@ -0,0 +1,49 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rerun_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup/internal/rerun"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Pattern(t *testing.T) {
+	assert.Equal(t, `^TestGroup$/^_$/^Method\.x$`, rerun.Pattern("TestGroup/_/Method.x"))
+}
+
+func Test_Args(t *testing.T) {
+	coverDir := flag.Lookup("test.gocoverdir")
+	require.NotNil(t, coverDir)
+
+	old := coverDir.Value.String()
+	require.NoError(t, flag.Set("test.gocoverdir", "/tmp/coverage"))
+
+	defer func() { require.NoError(t, flag.Set("test.gocoverdir", old)) }()
+
+	args := rerun.Args("TestGroup/Method")
+
+	assert.Equal(t, []string{"-test.run", "^TestGroup$/^Method$", "-test.v", "-test.count=1"}, args[:4])
+	assert.Contains(t, args, "-test.gocoverdir=/tmp/coverage",
+		"coverage data should go where go test collects it")
+	assert.Contains(t, args, "-test.timeout="+flag.Lookup("test.timeout").Value.String())
+
+	for _, arg := range args {
+		assert.NotContains(t, arg, "-test.coverprofile", "the new process would overwrite the profile")
+	}
+}
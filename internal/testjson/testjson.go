@@ -0,0 +1,111 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testjson reads the event stream written by "go test -json".
+package testjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is a single line of "go test -json" output. See "go doc cmd/test2json" for details.
+type Event struct {
+	Time    time.Time
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// Actions reported by "go test -json" that end a test.
+const (
+	ActionPass = "pass"
+	ActionFail = "fail"
+	ActionSkip = "skip"
+)
+
+// Action reported by "go test -json" when a test prints output.
+const ActionOutput = "output"
+
+// Action reported by "go test -json" in Go 1.24 and later when the go command prints build errors.
+const ActionBuildOutput = "build-output"
+
+// IsFinal reports whether the event marks the end of a test or package.
+func (e *Event) IsFinal() bool {
+	switch e.Action {
+	case ActionPass, ActionFail, ActionSkip:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsFraming reports whether an output event is one of the lines that the testing package prints
+// to mark a test starting, pausing, continuing, or finishing, as opposed to output from the test
+// itself.
+func (e *Event) IsFraming() bool {
+	if e.Action != ActionOutput {
+		return false
+	}
+
+	line := strings.TrimLeft(e.Output, " ")
+	for _, prefix := range []string{
+		"=== RUN ", "=== PAUSE ", "=== CONT ", "=== NAME ",
+		"--- PASS: ", "--- FAIL: ", "--- SKIP: ",
+	} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decode reads events from r and calls handle for each one. Lines that are not JSON objects, such
+// as build errors interleaved with the event stream, are passed to handleOther if it is not nil.
+func Decode(r io.Reader, handle func(Event), handleOther func(line string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.HasPrefix(bytes.TrimSpace(line), []byte("{")) {
+			if handleOther != nil {
+				handleOther(string(line))
+			}
+
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return fmt.Errorf("testjson: could not decode %q: %w", line, err)
+		}
+
+		handle(event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("testjson: error reading events: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/bloomberg/go-testgroup/internal/rerun"
+)
+
+// isolatedTestEnvVar is set to the full name of the test method that a subprocess started by the
+// Isolate option should run.
+const isolatedTestEnvVar = "TESTGROUP_ISOLATED_TEST"
+
+// Isolate runs each test method of the group in its own process, which is useful for methods that
+// change process-wide state that can't be changed back, like signal handlers, or that might call
+// os.Exit or crash.
+//
+// For each method, the test binary runs again with a -test.run pattern that matches only that
+// method. The group's hooks, including PreGroup and PostGroup, run in each subprocess, not in the
+// original process. The subprocess's output is logged by the method's subtest as it runs, and the
+// subtest passes, fails, or is skipped when the method does. A method that makes its subprocess
+// exit early, or crash, fails.
+//
+// The subprocess gets the original process's -test.short, -test.timeout, and -test.gocoverdir
+// flags, so with go test 1.20 and later, the coverage of isolated methods is included in the
+// -coverprofile. Other go test flags, like -failfast and -count, aren't passed on, since they don't
+// matter when the subprocess runs one method once.
+//
+// The test function that runs the group must run it the same way every time, so that the
+// subprocess finds the method. Isolate has no effect on groups that run on a TB other than a
+// *testing.T, like a Recorder.
+func Isolate() Option {
+	return func(o *options) { o.isolate = true }
+}
+
+// isolating reports whether the runner runs its test methods in subprocesses, rather than being a
+// subprocess that runs one of them. Groups that don't run on a *testing.T aren't isolated, since
+// the subprocess finds its method with go test's -test.run flag.
+func (r *runner) isolating() bool {
+	return r.opts.isolate && r.onTestingT && os.Getenv(isolatedTestEnvVar) == ""
+}
+
+// runIsolated runs a test method in a subprocess.
+func (r *runner) runIsolated(t *T) {
+	t.tb.Helper()
+
+	//nolint:gosec // os.Args[0] is this test binary
+	cmd := exec.CommandContext(t.Context(), os.Args[0], rerun.Args(t.Name())...)
+	cmd.Env = append(os.Environ(), isolatedTestEnvVar+"="+t.Name())
+
+	output, input, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("testgroup: could not run the test in a subprocess: %v", err)
+	}
+	defer output.Close()
+
+	cmd.Stdout, cmd.Stderr = input, input
+
+	err = cmd.Start()
+	input.Close()
+
+	if err != nil {
+		t.Fatalf("testgroup: could not run the test in a subprocess: %v", err)
+	}
+
+	ran, skipped := false, false
+
+	scanner := bufio.NewScanner(output)
+	scanner.Buffer(nil, 16<<20)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		t.log(line)
+
+		line = strings.TrimSpace(line)
+		ran = ran || line == "=== RUN   "+t.Name()
+		skipped = skipped || strings.HasPrefix(line, "--- SKIP: "+t.Name()+" (")
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Logf("testgroup: could not read the subprocess's output: %v", err)
+		_, _ = io.Copy(io.Discard, output)
+	}
+
+	var exitErr *exec.ExitError
+
+	switch err := cmd.Wait(); {
+	case errors.As(err, &exitErr):
+		t.Fatalf("testgroup: the test failed in its subprocess, which exited with %s.", exitErr.ProcessState)
+	case err != nil:
+		t.Fatalf("testgroup: could not run the test in a subprocess: %v", err)
+	case !ran:
+		t.Fatalf("testgroup: the subprocess didn't find the test."+
+			" Make sure that the test function runs %T the same way every time.", r.group)
+	case skipped:
+		t.Skip("testgroup: the test was skipped in its subprocess.")
+	}
+}
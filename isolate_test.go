@@ -0,0 +1,93 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+const isolateParentPIDEnvVar = "TESTGROUP_TEST_ISOLATE_PARENT_PID"
+
+func Test_Isolate(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		if os.Getenv(isolateParentPIDEnvVar) == "" {
+			t.Setenv(isolateParentPIDEnvVar, strconv.Itoa(os.Getpid()))
+		}
+
+		t.Run("Serial", func(t *testing.T) { testgroup.RunSerially(t, &IsolateGroup{}, testgroup.Isolate()) })
+		t.Run("Parallel", func(t *testing.T) { testgroup.RunInParallel(t, &IsolateGroup{}, testgroup.Isolate()) })
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	for _, group := range []string{"Serial", "Parallel"} {
+		assert.Contains(t, subtest(t, result, group).Output,
+			"testgroup: 2 of 4 tests failed and 1 skipped in Test_Isolate/"+group+"\n")
+	}
+
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Serial/Passes").Outcome)
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "Serial/Fails").Outcome)
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "Parallel/_/Exits").Outcome)
+	assert.Contains(t, subtest(t, result, "Parallel/_/Exits").Output,
+		"testgroup: the test failed in its subprocess, which exited with exit status 3.")
+
+	skips := subtest(t, result, "Serial/Skips")
+	assert.Equal(t, testgrouptest.Skipped, skips.Outcome)
+	assert.Contains(t, skips.Output, "testgroup: the test was skipped in its subprocess.")
+	assert.Contains(t, skips.Output, "skipping in the subprocess")
+
+	assert.NotContains(t, result.Output, "ran in the parent process")
+}
+
+type IsolateGroup struct{}
+
+func (*IsolateGroup) PreGroup(t *testgroup.T) {
+	requireSubprocess(t)
+}
+
+func (*IsolateGroup) PreTest(t *testgroup.T) {
+	requireSubprocess(t)
+}
+
+func (*IsolateGroup) Passes(t *testgroup.T) {}
+
+func (*IsolateGroup) Fails(t *testgroup.T) {
+	t.Fatal("failing in the subprocess")
+}
+
+func (*IsolateGroup) Skips(t *testgroup.T) {
+	t.Skip("skipping in the subprocess")
+}
+
+func (*IsolateGroup) Exits(t *testgroup.T) {
+	os.Exit(3)
+}
+
+func requireSubprocess(t *testgroup.T) {
+	parent, err := strconv.Atoi(os.Getenv(isolateParentPIDEnvVar))
+	t.Require.NoError(err)
+
+	if os.Getpid() == parent {
+		t.Fatal("ran in the parent process")
+	}
+}
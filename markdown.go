@@ -0,0 +1,221 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WriteMarkdownSummary writes a Markdown report of every group that has finished running in this
+// process: a table of groups with their pass/fail/skip counts and durations, the failures of each
+// failed test or hook in collapsible sections, the reasons tests were skipped, and the tests that
+// only passed after a retry. Groups run on a Recorder aren't included.
+//
+// It is meant to be called from TestMain after m.Run returns. See AppendMarkdownSummary for writing
+// a GitHub Actions job summary.
+func WriteMarkdownSummary(w io.Writer) error {
+	finishedGroups.Lock()
+	groups := append([]*groupResult{}, finishedGroups.groups...)
+	finishedGroups.Unlock()
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "### Test groups in `%s`\n\n", strings.TrimSuffix(filepath.Base(os.Args[0]), ".test"))
+
+	if len(groups) == 0 {
+		fmt.Fprintf(bw, "No test groups ran.\n\n")
+		return bw.Flush()
+	}
+
+	fmt.Fprintf(bw, "| | Group | Mode | Passed | Failed | Skipped | Duration |\n")
+	fmt.Fprintf(bw, "| --- | --- | --- | ---: | ---: | ---: | ---: |\n")
+
+	for _, g := range groups {
+		writeMarkdownGroupRow(bw, g)
+	}
+
+	fmt.Fprintln(bw)
+
+	for _, g := range groups {
+		writeMarkdownFailures(bw, g)
+	}
+
+	writeMarkdownSkips(bw, groups)
+	writeMarkdownFlakes(bw, groups)
+
+	return bw.Flush()
+}
+
+// AppendMarkdownSummary appends the report from WriteMarkdownSummary to the file at path, creating
+// it if necessary. To show the report on a GitHub Actions job's summary page, pass the value of the
+// GITHUB_STEP_SUMMARY environment variable:
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//
+//		if path := os.Getenv("GITHUB_STEP_SUMMARY"); path != "" {
+//			if err := testgroup.AppendMarkdownSummary(path); err != nil {
+//				fmt.Fprintln(os.Stderr, err)
+//			}
+//		}
+//
+//		os.Exit(code)
+//	}
+func AppendMarkdownSummary(path string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("testgroup: could not open Markdown summary: %w", err)
+	}
+
+	if err := WriteMarkdownSummary(f); err != nil {
+		f.Close()
+		return fmt.Errorf("testgroup: could not write Markdown summary: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("testgroup: could not write Markdown summary: %w", err)
+	}
+
+	return nil
+}
+
+func writeMarkdownGroupRow(w io.Writer, g *groupResult) {
+	status := ":white_check_mark:"
+
+	switch {
+	case g.failed():
+		status = ":x:"
+	case g.hooks.skipReason != "":
+		status = ":fast_forward:"
+	}
+
+	mode := "serial"
+	if g.parallel {
+		mode = "parallel"
+	} else if g.scenario {
+		mode = "scenario"
+	}
+
+	passed, failed, skipped := g.counts()
+
+	fmt.Fprintf(w, "| %s | %s | %s | %d | %d | %d | %s |\n",
+		status, markdownCode(g.name), mode, passed, failed, skipped,
+		g.duration.Round(time.Millisecond))
+}
+
+func writeMarkdownFailures(w io.Writer, g *groupResult) {
+	if g.hooks.failed {
+		writeMarkdownFailure(w, g.name, g.hooks.failedPhase, g.hooks.message)
+	}
+
+	for _, r := range g.ranTests() {
+		if r.failed {
+			writeMarkdownFailure(w, g.testName(r), r.failedPhase, r.message)
+		}
+	}
+}
+
+func writeMarkdownFailure(w io.Writer, testName, phase, message string) {
+	title := "<code>" + html.EscapeString(testName) + "</code>"
+	if phase != "" {
+		title += " (in " + phase + ")"
+	}
+
+	fmt.Fprintf(w, "<details>\n<summary>:x: %s: %s</summary>\n\n",
+		title, html.EscapeString(summarizeMessage(message)))
+
+	if strings.TrimSpace(message) != "" {
+		fence := "```"
+		for strings.Contains(message, fence) {
+			fence += "`"
+		}
+
+		fmt.Fprintf(w, "%stext\n%s\n%s\n\n", fence, strings.Trim(message, "\n"), fence)
+	}
+
+	fmt.Fprintf(w, "</details>\n\n")
+}
+
+func writeMarkdownSkips(w io.Writer, groups []*groupResult) {
+	var names, reasons []string
+
+	for _, g := range groups {
+		if g.hooks.skipReason != "" {
+			names = append(names, g.name)
+			reasons = append(reasons, g.hooks.skipReason)
+		}
+
+		for _, r := range g.ranTests() {
+			if r.skipped && !r.failed {
+				names = append(names, g.testName(r))
+				reasons = append(reasons, r.skipReason)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "Skipped tests:\n\n")
+
+	for i, name := range names {
+		reason := reasons[i]
+		if reason == "" {
+			reason = "no reason given"
+		}
+
+		fmt.Fprintf(w, "- %s: %s\n", markdownCode(name),
+			strings.ReplaceAll(html.EscapeString(reason), "\n", " "))
+	}
+
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownFlakes(w io.Writer, groups []*groupResult) {
+	header := false
+
+	for _, g := range groups {
+		for _, r := range g.ranTests() {
+			if !r.flaky {
+				continue
+			}
+
+			if !header {
+				fmt.Fprintf(w, "Flaky tests, which passed after a retry:\n\n")
+
+				header = true
+			}
+
+			fmt.Fprintf(w, "- %s: passed on attempt %d\n", markdownCode(g.testName(r)), r.attempts)
+		}
+	}
+
+	if header {
+		fmt.Fprintln(w)
+	}
+}
+
+// markdownCode formats s as inline code that is safe to put in a table cell.
+func markdownCode(s string) string {
+	return "<code>" + strings.ReplaceAll(html.EscapeString(s), "|", "&#124;") + "</code>"
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MarkdownSummary(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		t.Run("Parallel", func(t *testing.T) { testgroup.RunInParallel(t, &MarkdownSummary{}) })
+		t.Run("Serial", func(t *testing.T) { testgroup.RunSerially(t, &Subgroup{}) })
+		testgroup.Record("Recorded", func(t testgroup.TB) { testgroup.RunSeriallyOn(t, &MarkdownSummary{}) })
+
+		require.NoError(t, testgroup.WriteMarkdownSummary(os.Stdout))
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "").Outcome)
+
+	output := result.Output
+
+	assert.Contains(t, output, "### Test groups in `")
+	assert.Contains(t, output,
+		"| :x: | <code>Test_MarkdownSummary/Parallel</code> | parallel | 1 | 1 | 1 |")
+	assert.Contains(t, output,
+		"| :white_check_mark: | <code>Test_MarkdownSummary/Serial</code> | serial | 2 | 0 | 0 |")
+	assert.Contains(t, output,
+		"<summary>:x: <code>Test_MarkdownSummary/Parallel/_/Fails</code>: "+
+			"Not equal: expected: &#34;a&#34; actual : &#34;b&#34;</summary>")
+	assert.Contains(t, output, "+++ Actual", "the full message should include the diff")
+	assert.Contains(t, output, "```text\n\tError Trace:")
+	assert.Contains(t, output,
+		"- <code>Test_MarkdownSummary/Parallel/_/Skips</code>: not &lt;today&gt;")
+	assert.NotContains(t, output, "Recorded", "groups run on a Recorder shouldn't be reported")
+}
+
+type MarkdownSummary struct{}
+
+func (*MarkdownSummary) Fails(t *testgroup.T)  { t.Equal("a", "b") }
+func (*MarkdownSummary) Passes(t *testgroup.T) {}
+func (*MarkdownSummary) Skips(t *testgroup.T)  { t.Skip("not <today>") }
+
+func Test_AppendMarkdownSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+
+	require.NoError(t, testgroup.AppendMarkdownSummary(path))
+	require.NoError(t, testgroup.AppendMarkdownSummary(path))
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, strings.Count(string(contents), "### Test groups in"))
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+// An Option changes how RunSerially or RunInParallel runs a test group.
+type Option func(*options)
+
+type options struct {
+	bufferOutput         bool
+	tracePath            string
+	maxConcurrency       int
+	maxConcurrencyForTag map[string]int
+	failFast             bool
+	retry                retryPolicy
+	retryForTag          map[string]retryPolicy
+	focus                []string
+	detectGoroutineLeaks bool
+	ignoredGoroutines    []string
+	detectFileLeaks      bool
+	guardEnvironment     bool
+	globals              []guardedGlobal
+	isolate              bool
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
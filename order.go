@@ -0,0 +1,230 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// FindOrderDependence helps find test methods whose results depend on the methods that run before
+// them, which is a common reason for a test that passes by itself to fail in its group. It's a
+// diagnostic tool, meant to be run by hand in place of RunSerially:
+//
+//	func TestMyGroup(t *testing.T) {
+//		testgroup.FindOrderDependence(t, func() interface{} { return &MyGroup{} })
+//	}
+//
+// It runs all of the group's test methods in order, like RunSerially, in a subtest named InOrder,
+// and then each method by itself, in subtests named Alone/<method>. Every run gets a new group from
+// newGroup, with its own PreGroup and PostGroup. Then it fails if any method's result differs
+// between the two. When a method passes by itself but fails in order, it looks for the method that
+// makes it fail by running it after smaller and smaller sets of the methods that run before it, in
+// subtests named Bisect/<method>/<n>.
+//
+// The methods' failures fail their subtests as usual, so expect failures in the subtests even when
+// no order dependence is found.
+func FindOrderDependence(t *testing.T, newGroup func() interface{}, opts ...Option) {
+	t.Helper()
+
+	d := &orderDetector{newGroup: newGroup, opts: opts}
+
+	var inOrder *groupResult
+
+	t.Run("InOrder", func(t *testing.T) { inOrder = d.run(t, nil) })
+
+	if inOrder == nil || len(inOrder.ranTests()) == 0 {
+		t.Fatalf("testgroup: FindOrderDependence could not run the group's tests in order.")
+	}
+
+	var methods []string
+	for _, r := range inOrder.ranTests() {
+		methods = append(methods, r.name)
+	}
+
+	alone := map[string]string{}
+
+	t.Run("Alone", func(t *testing.T) {
+		for _, method := range methods {
+			method := method
+			t.Run(method, func(t *testing.T) { alone[method] = outcome(d.run(t, []string{method}), method) })
+		}
+	})
+
+	polluters := d.bisect(t, methods, inOrder, alone)
+	differences := 0
+
+	for _, method := range methods {
+		together := outcome(inOrder, method)
+		if alone[method] == together {
+			continue
+		}
+
+		differences++
+
+		message := fmt.Sprintf("testgroup: %s %s by itself, but %s after the group's other tests.",
+			method, alone[method], together)
+
+		switch {
+		case alone[method] == "passed" && together == "failed":
+			message += " " + polluters[method]
+		case together == "passed":
+			message += " It may depend on something that those tests do."
+		}
+
+		t.Error(message)
+	}
+
+	if differences == 0 {
+		t.Logf("testgroup: each test had the same result by itself as in order.")
+	}
+}
+
+// orderDetector runs parts of a group for FindOrderDependence.
+type orderDetector struct {
+	newGroup func() interface{}
+	opts     []Option
+}
+
+// run runs the given test methods of a new group, or all of them if methods is nil.
+func (d *orderDetector) run(t *testing.T, methods []string) *groupResult {
+	t.Helper()
+
+	tb := testingT{t}
+	r := newRunner(tb, false, d.newGroup(), newOptions(d.opts))
+
+	if methods != nil {
+		r.only = map[string]bool{}
+		for _, m := range methods {
+			r.only[m] = true
+		}
+	}
+
+	r.run(tb)
+
+	return r.result
+}
+
+// bisect runs findPolluters for each method that passes by itself but fails in order, in subtests
+// of a Bisect subtest, and returns what it found for each of them.
+func (d *orderDetector) bisect(t *testing.T, methods []string, inOrder *groupResult,
+	alone map[string]string) map[string]string {
+	t.Helper()
+
+	polluters := map[string]string{}
+
+	var victims []int
+
+	for i, method := range methods {
+		if alone[method] == "passed" && outcome(inOrder, method) == "failed" {
+			victims = append(victims, i)
+		}
+	}
+
+	if len(victims) == 0 {
+		return polluters
+	}
+
+	t.Run("Bisect", func(t *testing.T) {
+		for _, i := range victims {
+			method, before := methods[i], methods[:i]
+			t.Run(method, func(t *testing.T) { polluters[method] = d.findPolluters(t, method, before) })
+		}
+	})
+
+	return polluters
+}
+
+// findPolluters looks for the tests that make method fail when they run before it, and describes
+// what it found.
+func (d *orderDetector) findPolluters(t *testing.T, method string, before []string) string {
+	t.Helper()
+
+	candidates := before
+	step := 0
+
+	fails := func(tests []string) bool {
+		step++
+
+		var result *groupResult
+
+		t.Run(strconv.Itoa(step), func(t *testing.T) {
+			result = d.run(t, append(append([]string{}, tests...), method))
+		})
+
+		return outcome(result, method) == "failed"
+	}
+
+	for len(candidates) > 1 {
+		half := len(candidates) / 2
+
+		switch {
+		case fails(candidates[:half]):
+			candidates = candidates[:half]
+		case fails(candidates[half:]):
+			candidates = candidates[half:]
+		default:
+			return fmt.Sprintf("No half of the tests that run before it makes it fail,"+
+				" but it fails after these tests together: %s.", strings.Join(candidates, ", "))
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "No tests run before it, so the group's hooks may be to blame."
+	}
+
+	return fmt.Sprintf("It fails after %s.", candidates[0])
+}
+
+// outcome describes the result of a test method in a group.
+func outcome(g *groupResult, method string) string {
+	if g == nil {
+		return "did not run"
+	}
+
+	r := g.test(method)
+
+	switch {
+	case g.hooks.failed:
+		return "failed"
+	case r == nil || !r.ran:
+		return "did not run"
+	case r.failed:
+		return "failed"
+	case r.skipped:
+		return "was skipped"
+	default:
+		return "passed"
+	}
+}
+
+// onlyMethods drops the test methods that the runner shouldn't run, keeping their order.
+func (r *runner) onlyMethods() {
+	if r.only == nil {
+		return
+	}
+
+	methods := []testMethod{}
+
+	for _, m := range r.methods {
+		if r.only[m.Name] {
+			methods = append(methods, m)
+		}
+	}
+
+	r.methods = methods
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindOrderDependence(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.FindOrderDependence(t, func() interface{} { return &OrderGroup{} })
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "InOrder/C_Victim").Outcome)
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Alone/C_Victim/C_Victim").Outcome)
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "Bisect/C_Victim/1/C_Victim").Outcome)
+	assert.NotNil(t, result.Test(t.Name()+"/Bisect"), "each level of the bisection should be a subtest")
+	assert.NotNil(t, result.Test(t.Name()+"/Bisect/C_Victim"), "each level of the bisection should be a subtest")
+	assert.Nil(t, result.Test(t.Name()+"/Bisect/C_Victim/2"))
+	assert.Nil(t, result.Test(t.Name()+"/Bisect/D_NeedsSetup"))
+
+	output := subtest(t, result, "").Output
+
+	assert.Contains(t, output, "testgroup: C_Victim passed by itself, but failed after the group's other tests."+
+		" It fails after A_Pollutes.")
+	assert.Contains(t, output, "testgroup: D_NeedsSetup failed by itself, but passed after the group's other tests."+
+		" It may depend on something that those tests do.")
+	assert.NotContains(t, output, "testgroup: A_Pollutes ")
+	assert.NotContains(t, output, "testgroup: B_Innocent ")
+}
+
+//nolint:gochecknoglobals // This is the state that the group's tests pollute.
+var orderPolluted bool
+
+type OrderGroup struct{}
+
+func (*OrderGroup) PreGroup(t *testgroup.T) {
+	orderPolluted = false
+}
+
+func (*OrderGroup) A_Pollutes(t *testgroup.T) {
+	orderPolluted = true
+}
+
+func (*OrderGroup) B_Innocent(t *testgroup.T) {}
+
+func (*OrderGroup) C_Victim(t *testgroup.T) {
+	t.False(orderPolluted)
+}
+
+func (*OrderGroup) D_NeedsSetup(t *testgroup.T) {
+	t.True(orderPolluted)
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BufferOutput makes each test method hold on to its log output (including assertion failures)
+// until it finishes and then write it all at once, so that output from tests running in parallel is
+// not interleaved. Output from a method's subtests is written when each subtest finishes.
+//
+// A method that panics writes its buffered output before the panic is reported. A method that is
+// still running shortly before the go test -timeout deadline stops buffering and writes its output
+// as it happens.
+//
+// Output written directly to the embedded *testing.T (for example, t.T.Log) is not buffered.
+func BufferOutput() Option {
+	return func(o *options) { o.bufferOutput = true }
+}
+
+// outputDeadlineMargin is how long before the go test -timeout deadline buffered output switches to
+// streaming, so that it is not lost when the test binary panics.
+const outputDeadlineMargin = 5 * time.Second
+
+// output holds the log output of one test until the test finishes. See BufferOutput.
+type output struct {
+	t TB
+
+	mutex     sync.Mutex
+	lines     []string
+	streaming bool
+	done      bool
+	timer     *time.Timer
+}
+
+func newOutput(t TB) *output {
+	o := &output{t: t}
+
+	type deadliner interface{ Deadline() (time.Time, bool) }
+
+	if d, ok := t.(deadliner); ok {
+		if deadline, ok := d.Deadline(); ok {
+			o.timer = time.AfterFunc(time.Until(deadline)-outputDeadlineMargin, o.stream)
+		}
+	}
+
+	return o
+}
+
+// write buffers a line of output. location is the file:line that logged it, or empty if the
+// message already says where it came from (like testify failure messages).
+func (o *output) write(location, message string) {
+	o.t.Helper()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.streaming || o.done {
+		o.t.Log(strings.TrimSuffix(message, "\n"))
+		return
+	}
+
+	if location != "" {
+		message = location + ": " + message
+	}
+
+	o.lines = append(o.lines, strings.TrimSuffix(message, "\n"))
+}
+
+// stream writes the buffered output and makes later output go straight to the test.
+func (o *output) stream() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.done {
+		return
+	}
+
+	o.writeLines()
+	o.streaming = true
+}
+
+// flush writes the buffered output. It must be called before the test finishes.
+func (o *output) flush() {
+	o.t.Helper()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.timer != nil {
+		o.timer.Stop()
+	}
+
+	o.writeLines()
+	o.done = true
+}
+
+func (o *output) writeLines() {
+	o.t.Helper()
+
+	if len(o.lines) == 0 {
+		return
+	}
+
+	// A single call to Log keeps the lines together in the go test output.
+	o.t.Log("\n" + strings.Join(o.lines, "\n"))
+	o.lines = nil
+}
+
+// callerLocation returns the file:line of the function skip frames above its caller, formatted the
+// way the testing package formats it.
+func callerLocation(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "???:1"
+	}
+
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_BufferOutput runs itself in a subprocess so that it can check the verbose output.
+func Test_BufferOutput(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunInParallel(t, &BufferedOutput{}, testgroup.BufferOutput())
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+	require.Equal(t, 0, result.ExitCode, "combined output:\n%s", result.Output)
+
+	// Each method's lines should be next to each other, even though the methods ran in parallel.
+	lineRE := regexp.MustCompile(`output_test\.go:\d+: ([A-Z]) (\d)`)
+	lines := lineRE.FindAllStringSubmatch(result.Output, -1)
+	require.Len(t, lines, 9, "combined output:\n%s", result.Output)
+
+	for i := 0; i < len(lines); i += 3 {
+		for j, line := range lines[i : i+3] {
+			assert.Equal(t, lines[i][1], line[1], "combined output:\n%s", result.Output)
+			assert.Equal(t, strconv.Itoa(j+1), line[2])
+		}
+	}
+}
+
+type BufferedOutput struct{}
+
+func (*BufferedOutput) logSlowly(t *testgroup.T) {
+	for i := 1; i <= 3; i++ {
+		t.Logf("%s %d", t.Name()[len(t.Name())-1:], i)
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (g *BufferedOutput) A(t *testgroup.T) { g.logSlowly(t) }
+func (g *BufferedOutput) B(t *testgroup.T) { g.logSlowly(t) }
+func (g *BufferedOutput) C(t *testgroup.T) { g.logSlowly(t) }
@@ -0,0 +1,65 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import "time"
+
+// PendingInfo describes a test method that isn't ready to run yet. A group lists its pending
+// methods with a Pending method:
+//
+//	func (*MyGroup) Pending() map[string]testgroup.PendingInfo {
+//		return map[string]testgroup.PendingInfo{
+//			"ExportsCSV": {
+//				Reason:  "waiting for the new export API",
+//				Expires: time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC),
+//			},
+//		}
+//	}
+//
+// A pending method is skipped with its reason, without running it or its hooks. Once the expiry
+// date passes, the method fails instead, so that someone revisits it.
+type PendingInfo struct {
+	// Reason says why the method is pending.
+	Reason string
+
+	// Expires is when the method stops being skipped and starts failing. If it's zero, the method
+	// is skipped until it's removed from Pending.
+	Expires time.Time
+}
+
+// skipIfPending skips a test method if it's pending, or fails it if it was pending but expired.
+func (r *runner) skipIfPending(t *T, method string) {
+	t.tb.Helper()
+
+	info, ok := r.decls.pending[method]
+	if !ok {
+		return
+	}
+
+	t.result.markPending()
+
+	if info.Expires.IsZero() {
+		t.Skipf("testgroup: pending: %s", info.Reason)
+	}
+
+	expires := info.Expires.Format("2006-01-02")
+
+	if time.Now().Before(info.Expires) {
+		t.Skipf("testgroup: pending until %s: %s", expires, info.Reason)
+	}
+
+	t.Fatalf("testgroup: still pending after it expired on %s: %s. Finish the test or update %T.Pending.",
+		expires, info.Reason, r.group)
+}
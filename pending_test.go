@@ -0,0 +1,71 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Pending(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunSerially(t, &Pending{})
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.ElementsMatch(t, []string{"NoExpiry", "NotExpired"}, subtestNames(t, result, testgrouptest.Skipped))
+	assert.Equal(t, []string{"Expired"}, subtestNames(t, result, testgrouptest.Failed))
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Ready").Outcome)
+	assert.NotContains(t, result.Output, "should not run")
+
+	assert.Contains(t, subtest(t, result, "").Output, "testgroup: 1 of 4 tests failed and 2 skipped in Test_Pending\n"+
+		"            Expired: testgroup: still pending after it expired on 2000-01-01: needs a fix."+
+		" Finish the test or update *testgroup_test.Pending.Pending.\n"+
+		"            NoExpiry: pending: needs a design\n"+
+		"            NotExpired: pending until 2999-01-01: needs a feature\n")
+}
+
+type Pending struct{}
+
+func (*Pending) Pending() map[string]testgroup.PendingInfo {
+	return map[string]testgroup.PendingInfo{
+		"Expired": {
+			Reason:  "needs a fix",
+			Expires: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"NoExpiry": {Reason: "needs a design"},
+		"NotExpired": {
+			Reason:  "needs a feature",
+			Expires: time.Date(2999, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func (*Pending) PreTest(t *testgroup.T) {
+	if t.Name() != "Test_Pending/Ready" {
+		t.Fatal("should not run")
+	}
+}
+
+func (*Pending) Expired(t *testgroup.T)    { t.Fatal("should not run") }
+func (*Pending) NoExpiry(t *testgroup.T)   { t.Fatal("should not run") }
+func (*Pending) NotExpired(t *testgroup.T) { t.Fatal("should not run") }
+func (*Pending) Ready(t *testgroup.T)      {}
@@ -0,0 +1,514 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Recorder is a TB that records what happens to a test and its subtests instead of reporting it
+// to the testing package, so that a group's failures don't fail the test that runs it. It's useful
+// for testing shared groups, hooks, and options:
+//
+//	rec := testgroup.Record("MyGroup", func(t testgroup.TB) {
+//		testgroup.RunSeriallyOn(t, &MyGroup{})
+//	})
+//
+//	assert.True(t, rec.Subtest("Method").Failed())
+//	assert.Equal(t, []string{"MyGroup PreGroup", "MyGroup/Method", "MyGroup PostGroup"}, rec.Phases())
+//
+// Like a *testing.T, a Recorder runs each subtest in its own goroutine, so that FailNow and SkipNow
+// can stop it, and a subtest that calls Parallel runs after its parent's function returns. Unlike
+// a *testing.T, it records panics as failures, and it doesn't limit how many parallel subtests run
+// at once. A Recorder doesn't need go test, so it can also run groups in other programs.
+type Recorder struct {
+	// testing.TB is always nil. It's embedded because testing.TB has an unexported method.
+	testing.TB
+
+	name    string
+	parent  *Recorder
+	events  *eventLog // shared by the test and its subtests
+	ctx     context.Context
+	cancel  context.CancelFunc
+	paused  chan struct{}
+	release chan struct{}
+	done    chan struct{}
+
+	mutex    sync.Mutex
+	failed   bool
+	skipped  bool
+	parallel bool
+	start    time.Time
+	end      time.Time
+	logs     []string
+	subtests []*Recorder
+	cleanups []func()
+}
+
+// Event is something that happened to a recorded test.
+type Event struct {
+	Test    string // the full name of the test, like "MyGroup/Method"
+	Kind    EventKind
+	Message string
+}
+
+// EventKind is the kind of an Event.
+type EventKind string
+
+// The kinds of events that a Recorder records.
+const (
+	// EventRun means that the test started.
+	EventRun EventKind = "run"
+
+	// EventPhase means that testgroup ran one of the group's hooks, like "PreTest", the test
+	// method itself, for which Message is empty, or one of its own checks, like "goroutine leak
+	// check". Message is the name of the phase.
+	EventPhase EventKind = "phase"
+
+	// EventLog means that the test logged Message, which includes failure and skip messages.
+	EventLog EventKind = "log"
+
+	// EventFail means that the test was marked as failed.
+	EventFail EventKind = "fail"
+
+	// EventSkip means that the test was marked as skipped.
+	EventSkip EventKind = "skip"
+
+	// EventDone means that the test and its subtests finished. Message is "pass", "fail", or
+	// "skip".
+	EventDone EventKind = "done"
+)
+
+type eventLog struct {
+	mutex  sync.Mutex
+	events []Event
+}
+
+func (l *eventLog) add(test string, kind EventKind, message string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.events = append(l.events, Event{Test: test, Kind: kind, Message: message})
+}
+
+// phaseRecorder is implemented by TBs that record the phases that testgroup runs.
+type phaseRecorder interface {
+	recordPhase(phase string)
+}
+
+// Record runs f with a new Recorder named name, like testing.T.Run runs a subtest, and returns the
+// Recorder after f and its subtests finish.
+func Record(name string, f func(t TB)) *Recorder {
+	r := StartRecording(context.Background(), name, f)
+	<-r.Done()
+
+	return r
+}
+
+// StartRecording starts running f with a new Recorder named name, like Record, but returns without
+// waiting for it to finish. The test's Context is derived from ctx.
+func StartRecording(ctx context.Context, name string, f func(t TB)) *Recorder {
+	r := newRecorder(ctx, name, nil, &eventLog{})
+	r.startRunning(f)
+
+	return r
+}
+
+func newRecorder(ctx context.Context, name string, parent *Recorder, events *eventLog) *Recorder {
+	r := &Recorder{
+		name:    name,
+		parent:  parent,
+		events:  events,
+		paused:  make(chan struct{}),
+		release: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	r.ctx, r.cancel = context.WithCancel(ctx)
+
+	return r
+}
+
+// startRunning runs f in a new goroutine.
+func (r *Recorder) startRunning(f func(t TB)) {
+	r.mutex.Lock()
+	r.start = time.Now()
+	r.mutex.Unlock()
+
+	r.events.add(r.name, EventRun, "")
+
+	go func() {
+		defer r.finish()
+
+		defer func() {
+			if v := recover(); v != nil {
+				r.Errorf("panic: %v\n%s", v, debug.Stack())
+			}
+		}()
+
+		f(r)
+	}()
+}
+
+// finish waits for the test's parallel subtests, runs its cleanup functions, and marks it done.
+func (r *Recorder) finish() {
+	for _, sub := range r.Subtests() {
+		if sub.isParallel() {
+			close(sub.release)
+		}
+	}
+
+	for _, sub := range r.Subtests() {
+		<-sub.done
+	}
+
+	r.cancel()
+
+	r.mutex.Lock()
+	cleanups := r.cleanups
+	r.cleanups = nil
+	r.mutex.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+
+	outcome := "pass"
+
+	switch {
+	case r.Failed():
+		outcome = "fail"
+
+		if r.parent != nil {
+			r.parent.setFailed()
+		}
+	case r.Skipped():
+		outcome = "skip"
+	}
+
+	r.mutex.Lock()
+	r.end = time.Now()
+	r.mutex.Unlock()
+
+	r.events.add(r.name, EventDone, outcome)
+	close(r.done)
+}
+
+func (r *Recorder) isParallel() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.parallel
+}
+
+// setFailed marks the test as failed, and reports whether it already was.
+func (r *Recorder) setFailed() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	failed := r.failed
+	r.failed = true
+
+	return failed
+}
+
+func (r *Recorder) recordPhase(phase string) {
+	r.events.add(r.name, EventPhase, phase)
+}
+
+// Run runs f as a subtest, like testing.T.Run, and reports whether it succeeded, or at least
+// hadn't failed before it called Parallel.
+func (r *Recorder) Run(name string, f func(t TB)) bool {
+	sub := newRecorder(r.ctx, r.name+"/"+name, r, r.events)
+
+	r.mutex.Lock()
+	r.subtests = append(r.subtests, sub)
+	r.mutex.Unlock()
+
+	sub.startRunning(f)
+
+	select {
+	case <-sub.done:
+	case <-sub.paused:
+	}
+
+	return !sub.Failed()
+}
+
+// Parallel pauses the test until its parent's function returns, like testing.T.Parallel. It
+// doesn't pause a test that has no parent.
+func (r *Recorder) Parallel() {
+	r.mutex.Lock()
+	r.parallel = true
+	r.mutex.Unlock()
+
+	if r.parent == nil {
+		return
+	}
+
+	close(r.paused)
+	<-r.release
+}
+
+// Context returns a context that is canceled after the test's function and its subtests finish,
+// just before its cleanup functions run, or when the context that it was derived from is
+// canceled.
+func (r *Recorder) Context() context.Context {
+	return r.ctx
+}
+
+// Done returns a channel that is closed when the test and its subtests finish.
+func (r *Recorder) Done() <-chan struct{} {
+	return r.done
+}
+
+// Elapsed returns how long the test and its subtests took, or how long they've been running if
+// they haven't finished.
+func (r *Recorder) Elapsed() time.Duration {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.end.IsZero() {
+		return time.Since(r.start)
+	}
+
+	return r.end.Sub(r.start)
+}
+
+// Name returns the full name of the test.
+func (r *Recorder) Name() string {
+	return r.name
+}
+
+// Helper does nothing, since a Recorder doesn't report where messages were logged.
+func (r *Recorder) Helper() {}
+
+// Log records a message, like testing.T.Log.
+func (r *Recorder) Log(args ...interface{}) {
+	r.log(fmt.Sprintln(args...))
+}
+
+// Logf records a message, like testing.T.Logf.
+func (r *Recorder) Logf(format string, args ...interface{}) {
+	r.log(fmt.Sprintf(format, args...))
+}
+
+func (r *Recorder) log(message string) {
+	message = strings.TrimSuffix(message, "\n")
+
+	r.mutex.Lock()
+	r.logs = append(r.logs, message)
+	r.mutex.Unlock()
+
+	r.events.add(r.name, EventLog, message)
+}
+
+// Fail marks the test as failed, like testing.T.Fail.
+func (r *Recorder) Fail() {
+	if !r.setFailed() {
+		r.events.add(r.name, EventFail, "")
+	}
+}
+
+// FailNow marks the test as failed and stops it, like testing.T.FailNow.
+func (r *Recorder) FailNow() {
+	r.Fail()
+	runtime.Goexit()
+}
+
+// Error records a message and marks the test as failed, like testing.T.Error.
+func (r *Recorder) Error(args ...interface{}) {
+	r.Log(args...)
+	r.Fail()
+}
+
+// Errorf records a message and marks the test as failed, like testing.T.Errorf.
+func (r *Recorder) Errorf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.Fail()
+}
+
+// Fatal records a message, marks the test as failed, and stops it, like testing.T.Fatal.
+func (r *Recorder) Fatal(args ...interface{}) {
+	r.Log(args...)
+	r.FailNow()
+}
+
+// Fatalf records a message, marks the test as failed, and stops it, like testing.T.Fatalf.
+func (r *Recorder) Fatalf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.FailNow()
+}
+
+// SkipNow marks the test as skipped and stops it, like testing.T.SkipNow.
+func (r *Recorder) SkipNow() {
+	r.mutex.Lock()
+	r.skipped = true
+	r.mutex.Unlock()
+
+	r.events.add(r.name, EventSkip, "")
+	runtime.Goexit()
+}
+
+// Skip records a message, marks the test as skipped, and stops it, like testing.T.Skip.
+func (r *Recorder) Skip(args ...interface{}) {
+	r.Log(args...)
+	r.SkipNow()
+}
+
+// Skipf records a message, marks the test as skipped, and stops it, like testing.T.Skipf.
+func (r *Recorder) Skipf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.SkipNow()
+}
+
+// Failed reports whether the test, or one of its finished subtests, failed.
+func (r *Recorder) Failed() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.failed
+}
+
+// Skipped reports whether the test was skipped.
+func (r *Recorder) Skipped() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.skipped
+}
+
+// Cleanup registers a function to call when the test and its subtests finish, like
+// testing.T.Cleanup.
+func (r *Recorder) Cleanup(f func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cleanups = append(r.cleanups, f)
+}
+
+// TempDir returns a new temporary directory that is removed when the test finishes, like
+// testing.T.TempDir.
+func (r *Recorder) TempDir() string {
+	dir, err := os.MkdirTemp("", strings.ReplaceAll(r.name, "/", "_"))
+	if err != nil {
+		r.Fatalf("TempDir: %v", err)
+	}
+
+	r.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return dir
+}
+
+// Setenv sets an environment variable until the test finishes, like testing.T.Setenv.
+func (r *Recorder) Setenv(key, value string) {
+	previous, ok := os.LookupEnv(key)
+
+	if err := os.Setenv(key, value); err != nil {
+		r.Fatalf("Setenv: %v", err)
+	}
+
+	r.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, previous)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+// Logs returns the messages that the test logged, including failure and skip messages, but not
+// the ones its subtests logged.
+func (r *Recorder) Logs() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]string{}, r.logs...)
+}
+
+// Subtests returns the test's subtests, in the order that they started.
+func (r *Recorder) Subtests() []*Recorder {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]*Recorder{}, r.subtests...)
+}
+
+// Subtest returns the subtest with the given name relative to the test, like "Method" or
+// "_/Method", or nil if there isn't one.
+func (r *Recorder) Subtest(name string) *Recorder {
+	test := r
+
+	for _, part := range strings.Split(name, "/") {
+		var found *Recorder
+
+		for _, sub := range test.Subtests() {
+			if sub.name == test.name+"/"+part {
+				found = sub
+			}
+		}
+
+		if found == nil {
+			return nil
+		}
+
+		test = found
+	}
+
+	return test
+}
+
+// Events returns what happened to the test and its subtests, in order.
+func (r *Recorder) Events() []Event {
+	r.events.mutex.Lock()
+	defer r.events.mutex.Unlock()
+
+	events := []Event{}
+
+	for _, e := range r.events.events {
+		if e.Test == r.name || strings.HasPrefix(e.Test, r.name+"/") {
+			events = append(events, e)
+		}
+	}
+
+	return events
+}
+
+// Phases returns the phases that testgroup ran in the test and its subtests, in order, each as the
+// name of the test followed by the phase, or just the name of the test for the test method itself,
+// like "MyGroup PreGroup", "MyGroup/Method PreTest", and "MyGroup/Method".
+func (r *Recorder) Phases() []string {
+	phases := []string{}
+
+	for _, e := range r.Events() {
+		switch {
+		case e.Kind != EventPhase:
+		case e.Message == "":
+			phases = append(phases, e.Test)
+		default:
+			phases = append(phases, e.Test+" "+e.Message)
+		}
+	}
+
+	return phases
+}
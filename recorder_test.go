@@ -0,0 +1,202 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Recorder_Serial(t *testing.T) {
+	rec := testgroup.Record("Group", func(t testgroup.TB) { testgroup.RunSeriallyOn(t, &RecordedGroup{}) })
+
+	assert.Equal(t,
+		[]string{
+			"Group PreGroup",
+			"Group/Fails PreTest", "Group/Fails", "Group/Fails PostTest",
+			"Group/Passes PreTest", "Group/Passes", "Group/Passes PostTest",
+			"Group/Skips PreTest", "Group/Skips", "Group/Skips PostTest",
+			"Group PostGroup",
+		},
+		rec.Phases())
+
+	assert.True(t, rec.Failed())
+	assert.Equal(t, []string{"testgroup: 1 of 3 tests failed and 1 skipped in Group\n    Fails: failing on purpose"},
+		rec.Logs())
+
+	fails := rec.Subtest("Fails")
+	require.NotNil(t, fails)
+	assert.True(t, fails.Failed())
+	assert.Equal(t, []string{"failing on purpose"}, fails.Logs())
+	assert.Equal(t, []string{"passes"}, rec.Subtest("Fails/Subtest").Logs())
+
+	assert.False(t, rec.Subtest("Passes").Failed())
+	assert.False(t, rec.Subtest("Passes").Skipped())
+	assert.True(t, rec.Subtest("Skips").Skipped())
+	assert.Equal(t, []string{"skipping on purpose"}, rec.Subtest("Skips").Logs())
+	assert.Nil(t, rec.Subtest("Missing"))
+
+	assert.Equal(t,
+		[]testgroup.Event{
+			{Test: "Group/Skips", Kind: testgroup.EventRun},
+			{Test: "Group/Skips", Kind: testgroup.EventPhase, Message: "PreTest"},
+			{Test: "Group/Skips", Kind: testgroup.EventPhase},
+			{Test: "Group/Skips", Kind: testgroup.EventLog, Message: "skipping on purpose"},
+			{Test: "Group/Skips", Kind: testgroup.EventSkip},
+			{Test: "Group/Skips", Kind: testgroup.EventPhase, Message: "PostTest"},
+			{Test: "Group/Skips", Kind: testgroup.EventDone, Message: "skip"},
+		},
+		rec.Subtest("Skips").Events())
+}
+
+func Test_Recorder_Parallel(t *testing.T) {
+	rec := testgroup.Record("Group", func(t testgroup.TB) { testgroup.RunInParallelOn(t, &RecordedGroup{}) })
+
+	phases := rec.Phases()
+	require.NotEmpty(t, phases)
+	assert.Equal(t, "Group PreGroup", phases[0])
+	assert.Equal(t, "Group PostGroup", phases[len(phases)-1])
+	assert.Len(t, phases, 11)
+
+	assert.True(t, rec.Failed())
+	assert.True(t, rec.Subtest(testgroup.RunInParallelParentTestName).Failed())
+	assert.True(t, rec.Subtest(testgroup.RunInParallelParentTestName+"/Fails").Failed())
+	assert.False(t, rec.Subtest(testgroup.RunInParallelParentTestName+"/Passes").Failed())
+	assert.True(t, rec.Subtest(testgroup.RunInParallelParentTestName+"/Skips").Skipped())
+}
+
+func Test_Recorder_Panic(t *testing.T) {
+	rec := testgroup.Record("Test", func(t testgroup.TB) {
+		t.Run("Panics", func(t testgroup.TB) { panic("panicking on purpose") })
+	})
+
+	assert.True(t, rec.Failed())
+	assert.True(t, rec.Subtest("Panics").Failed())
+	assert.Contains(t, rec.Subtest("Panics").Logs()[0], "panic: panicking on purpose")
+}
+
+func Test_Recorder_Cleanup(t *testing.T) {
+	var order []string
+
+	rec := testgroup.Record("Test", func(t testgroup.TB) {
+		t.Cleanup(func() { order = append(order, "parent cleanup") })
+
+		t.Run("Parallel", func(t testgroup.TB) {
+			t.Cleanup(func() { order = append(order, "subtest cleanup") })
+			t.Parallel()
+			order = append(order, "subtest")
+		})
+
+		order = append(order, "parent")
+	})
+
+	assert.False(t, rec.Failed())
+	assert.Equal(t, []string{"parent", "subtest", "subtest cleanup", "parent cleanup"}, order)
+}
+
+func Test_Recorder_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	rec := testgroup.StartRecording(ctx, "Group", func(t testgroup.TB) {
+		testgroup.RunSeriallyOn(t, &ContextGroup{})
+	})
+
+	cancel()
+	<-rec.Done()
+
+	assert.True(t, rec.Failed())
+	assert.Equal(t, []string{"context canceled"}, rec.Subtest("WaitsForCancel").Logs())
+	assert.True(t, rec.Elapsed() > 0)
+}
+
+func Test_Recorder_TestingTMethods(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	group := &TestingTMethods{}
+	rec := testgroup.StartRecording(ctx, "Group", func(t testgroup.TB) { testgroup.RunSeriallyOn(t, group) })
+	<-rec.Done()
+
+	assert.False(t, rec.Failed(), "logs: %v", rec.Subtest("Methods").Logs())
+	assert.True(t, group.hasDeadline)
+	assert.True(t, deadline.Equal(group.deadline), "got deadline %v", group.deadline)
+	assert.Equal(t, []string{"parent", "subtest"}, group.order)
+}
+
+// TestingTMethods calls methods of *testing.T that T has its own versions of, so that they work on
+// a Recorder.
+type TestingTMethods struct {
+	deadline    time.Time
+	hasDeadline bool
+
+	mutex sync.Mutex
+	order []string
+}
+
+func (g *TestingTMethods) Methods(t *testgroup.T) {
+	g.deadline, g.hasDeadline = t.Deadline()
+
+	t.Run("Parallel", func(t *testgroup.T) {
+		t.Parallel()
+		g.ran("subtest")
+	})
+
+	g.ran("parent")
+}
+
+func (g *TestingTMethods) ran(name string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.order = append(g.order, name)
+}
+
+type ContextGroup struct{}
+
+func (*ContextGroup) WaitsForCancel(t *testgroup.T) {
+	<-t.Context().Done()
+	t.Fatal(t.Context().Err())
+}
+
+// RecordedGroup is run on a Recorder.
+type RecordedGroup struct{}
+
+func (*RecordedGroup) PreGroup(t *testgroup.T)  {}
+func (*RecordedGroup) PostGroup(t *testgroup.T) {}
+func (*RecordedGroup) PreTest(t *testgroup.T)   {}
+func (*RecordedGroup) PostTest(t *testgroup.T)  {}
+
+func (*RecordedGroup) Fails(t *testgroup.T) {
+	t.Run("Subtest", func(t *testgroup.T) { t.Log("passes") })
+	t.Fatal("failing on purpose")
+	t.Log("should not run")
+}
+
+func (*RecordedGroup) Passes(t *testgroup.T) {
+	t.Helper()
+	t.Equal(2, 1+1)
+}
+
+func (*RecordedGroup) Skips(t *testgroup.T) {
+	t.Skip("skipping on purpose")
+}
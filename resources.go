@@ -0,0 +1,135 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"sync"
+	"time"
+)
+
+// A Resource is something that a test method uses and that other methods of the group may use too,
+// like a port, a file, or an environment variable. A group declares which resources its methods use
+// with a Resources method:
+//
+//	func (*MyGroup) Resources() map[string][]testgroup.Resource {
+//		return map[string][]testgroup.Resource{
+//			"StartsServer":   {testgroup.Exclusive("port:8080")},
+//			"ReadsConfig":    {testgroup.Shared("config.yaml")},
+//			"RewritesConfig": {testgroup.Exclusive("config.yaml")},
+//		}
+//	}
+//
+// RunInParallel never runs a method while another method holds a conflicting claim on one of its
+// resources: any number of methods can share a resource, but a method that uses it exclusively
+// runs alone. Methods that don't conflict still run in parallel. The claims cover each method's
+// PreTest and PostTest hooks as well as the method itself.
+type Resource struct {
+	Name      string
+	Exclusive bool
+}
+
+// Shared returns a Resource that a test method can use at the same time as other methods that
+// share it.
+func Shared(name string) Resource {
+	return Resource{Name: name, Exclusive: false}
+}
+
+// Exclusive returns a Resource that a test method must not use at the same time as any other method
+// that uses it.
+func Exclusive(name string) Resource {
+	return Resource{Name: name, Exclusive: true}
+}
+
+// resourceLocks enforces the group's Resources declarations.
+type resourceLocks struct {
+	mutex     sync.Mutex
+	changed   *sync.Cond
+	shared    map[string]int  // how many methods are sharing each resource
+	exclusive map[string]bool // which resources a method is using exclusively
+}
+
+func newResourceLocks() *resourceLocks {
+	l := &resourceLocks{shared: map[string]int{}, exclusive: map[string]bool{}}
+	l.changed = sync.NewCond(&l.mutex)
+
+	return l
+}
+
+// acquire waits until none of a method's resources are in use by a conflicting method, then claims
+// all of them at once. It returns how long it waited and a function to call when the method is
+// done.
+func (l *resourceLocks) acquire(resources []Resource) (waited time.Duration, release func()) {
+	if len(resources) == 0 {
+		return 0, func() {}
+	}
+
+	// If a method lists a resource more than once, an exclusive claim wins.
+	claims := map[string]bool{}
+	for _, r := range resources {
+		claims[r.Name] = claims[r.Name] || r.Exclusive
+	}
+
+	start := time.Now()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	blocked := false
+
+	for !l.available(claims) {
+		blocked = true
+
+		l.changed.Wait()
+	}
+
+	if blocked {
+		waited = time.Since(start)
+	}
+
+	for name, exclusive := range claims {
+		if exclusive {
+			l.exclusive[name] = true
+		} else {
+			l.shared[name]++
+		}
+	}
+
+	return waited, func() { l.release(claims) }
+}
+
+func (l *resourceLocks) available(claims map[string]bool) bool {
+	for name, exclusive := range claims {
+		if l.exclusive[name] || (exclusive && l.shared[name] > 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l *resourceLocks) release(claims map[string]bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for name, exclusive := range claims {
+		if exclusive {
+			delete(l.exclusive, name)
+		} else {
+			l.shared[name]--
+		}
+	}
+
+	l.changed.Broadcast()
+}
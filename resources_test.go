@@ -0,0 +1,100 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Resources(t *testing.T) {
+	g := &Resources{}
+	testgroup.RunInParallel(t, g)
+
+	assert.Empty(t, g.conflicts)
+
+	if goTestParallel() > 2 {
+		assert.Greater(t, g.maxSharing, 1, "methods sharing the port should run together")
+	}
+}
+
+// Resources checks that methods using the port exclusively never overlap with other methods using
+// the port.
+type Resources struct {
+	mutex      sync.Mutex
+	exclusive  int
+	sharing    int
+	maxSharing int
+	conflicts  []string
+}
+
+func (g *Resources) Resources() map[string][]testgroup.Resource {
+	return map[string][]testgroup.Resource{
+		"ExclusiveA": {testgroup.Exclusive("port")},
+		"ExclusiveB": {testgroup.Exclusive("port"), testgroup.Shared("port")},
+		"SharedC":    {testgroup.Shared("port")},
+		"SharedD":    {testgroup.Shared("port"), testgroup.Shared("file")},
+		"SharedE":    {testgroup.Shared("port")},
+	}
+}
+
+func (g *Resources) PreTest(t *testgroup.T) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	switch {
+	case strings.Contains(t.Name(), "Exclusive"):
+		if g.exclusive > 0 || g.sharing > 0 {
+			g.conflicts = append(g.conflicts, t.Name())
+		}
+
+		g.exclusive++
+	case strings.Contains(t.Name(), "Shared"):
+		if g.exclusive > 0 {
+			g.conflicts = append(g.conflicts, t.Name())
+		}
+
+		g.sharing++
+		if g.sharing > g.maxSharing {
+			g.maxSharing = g.sharing
+		}
+	}
+}
+
+func (g *Resources) PostTest(t *testgroup.T) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	switch {
+	case strings.Contains(t.Name(), "Exclusive"):
+		g.exclusive--
+	case strings.Contains(t.Name(), "Shared"):
+		g.sharing--
+	}
+}
+
+func (g *Resources) work(t *testgroup.T) { time.Sleep(20 * time.Millisecond) }
+
+func (g *Resources) ExclusiveA(t *testgroup.T) { g.work(t) }
+func (g *Resources) ExclusiveB(t *testgroup.T) { g.work(t) }
+func (g *Resources) SharedC(t *testgroup.T)    { g.work(t) }
+func (g *Resources) SharedD(t *testgroup.T)    { g.work(t) }
+func (g *Resources) SharedE(t *testgroup.T)    { g.work(t) }
+func (g *Resources) Unrelated(t *testgroup.T)  { g.work(t) }
@@ -0,0 +1,412 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bloomberg/go-testgroup/internal/rerun"
+)
+
+// groupResult records what happened when a group ran.
+type groupResult struct {
+	name     string // the name of the group's *testing.T
+	parallel bool
+	scenario bool
+	hooks    *testResult // failures in PreGroup and PostGroup
+	tests    []*testResult
+	start    time.Time
+	duration time.Duration
+}
+
+// finishedGroups holds the results of every group that has finished running in this process, for
+// reports like WriteMarkdownSummary.
+//
+//nolint:gochecknoglobals // Reports cover every group in the process.
+var finishedGroups = struct {
+	sync.Mutex
+	groups []*groupResult
+}{}
+
+// testResult records what happened to a test method, or to the group-level hooks.
+type testResult struct {
+	name     string
+	parallel bool // whether the test is a subtest of RunInParallelParentTestName
+
+	mutex       sync.Mutex
+	phase       string // the hook that is running, or "" while the method itself is running
+	failed      bool
+	failedPhase string
+	message     string // the first failure message
+	skipped     bool
+	skipReason  string
+	ran         bool // false if go test -run filtered the test out
+	attempts    int  // how many times the Retry option ran the test, or 0
+	flaky       bool // whether the test passed after a retry
+	pending     bool // whether the group's Pending method lists the test
+	start       time.Time
+	duration    time.Duration
+}
+
+func newGroupResult(t TB, parallel bool) *groupResult {
+	return &groupResult{
+		name:     t.Name(),
+		parallel: parallel,
+		hooks:    &testResult{},
+		start:    time.Now(),
+	}
+}
+
+// finished records that the group is done running.
+func (g *groupResult) finished() {
+	g.duration = time.Since(g.start)
+
+	finishedGroups.Lock()
+	finishedGroups.groups = append(finishedGroups.groups, g)
+	finishedGroups.Unlock()
+}
+
+// testName returns the full name of one of the group's tests, like go test -v shows it.
+func (g *groupResult) testName(r *testResult) string {
+	if r.parallel {
+		return g.name + "/" + RunInParallelParentTestName + "/" + r.name
+	}
+
+	return g.name + "/" + r.name
+}
+
+// ranTests returns the group's tests that were not filtered out by go test -run.
+func (g *groupResult) ranTests() []*testResult {
+	ran := make([]*testResult, 0, len(g.tests))
+
+	for _, r := range g.tests {
+		if r.ran {
+			ran = append(ran, r)
+		}
+	}
+
+	return ran
+}
+
+// counts returns how many of the group's tests passed, failed, and were skipped.
+func (g *groupResult) counts() (passed, failed, skipped int) {
+	for _, r := range g.ranTests() {
+		switch {
+		case r.failed:
+			failed++
+		case r.skipped:
+			skipped++
+		default:
+			passed++
+		}
+	}
+
+	return passed, failed, skipped
+}
+
+// failed reports whether any of the group's tests or hooks failed.
+func (g *groupResult) failed() bool {
+	_, failed, _ := g.counts()
+	return failed > 0 || g.hooks.failed
+}
+
+// test returns the result of the group's test with the given name, or nil if it hasn't started.
+func (g *groupResult) test(name string) *testResult {
+	for _, r := range g.tests {
+		if r.name == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func (g *groupResult) newTest(name string, parallel bool) *testResult {
+	r := &testResult{name: name, parallel: parallel}
+	g.tests = append(g.tests, r)
+
+	return r
+}
+
+// runPhase runs a hook (or, if phase is "", a test method) and records whether it failed.
+func (r *testResult) runPhase(t TB, phase string, f func()) {
+	t.Helper()
+
+	r.mutex.Lock()
+	r.phase = phase
+	r.mutex.Unlock()
+
+	failedBefore := t.Failed()
+
+	defer func() {
+		if !failedBefore && t.Failed() {
+			r.recordFailure("")
+		}
+
+		r.mutex.Lock()
+		r.phase = ""
+		r.mutex.Unlock()
+	}()
+
+	f()
+}
+
+// recordFailure notes that the test failed during the current phase. message is the failure
+// message if there is one. Only the first failure is kept.
+func (r *testResult) recordFailure(message string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.failed {
+		return
+	}
+
+	r.failed = true
+	r.failedPhase = r.phase
+	r.message = message
+}
+
+func (r *testResult) recordSkip(reason string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.skipReason == "" {
+		r.skipReason = strings.TrimSpace(reason)
+	}
+}
+
+// attempted records that the test has started its nth attempt.
+func (r *testResult) attempted(n int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.attempts = n
+}
+
+func (r *testResult) markPending() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.pending = true
+}
+
+func (r *testResult) started() {
+	r.ran = true
+	r.start = time.Now()
+}
+
+// finished records the final state of a test method. It must be called before the test finishes.
+func (r *testResult) finished(t TB) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.duration = time.Since(r.start)
+	r.skipped = t.Skipped()
+	r.flaky = r.attempts > 1 && !t.Failed() && !t.Skipped()
+
+	if t.Failed() && !r.failed {
+		r.failed = true
+		r.failedPhase = r.phase
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// logSummary logs which of the group's tests and hooks failed and how to rerun them, and which of
+// its tests are pending. It logs nothing if nothing failed and no tests are pending.
+func (g *groupResult) logSummary(t TB) {
+	t.Helper()
+
+	var failed, pending []*testResult
+
+	for _, r := range g.ranTests() {
+		switch {
+		case r.failed:
+			failed = append(failed, r)
+		case r.pending && r.skipped:
+			pending = append(pending, r)
+		}
+	}
+
+	if len(failed) == 0 && len(pending) == 0 && !g.hooks.failed {
+		return
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "testgroup: %d of %d tests failed", len(failed), len(g.ranTests()))
+
+	if _, _, skipped := g.counts(); skipped > 0 {
+		fmt.Fprintf(&b, " and %d skipped", skipped)
+	}
+
+	fmt.Fprintf(&b, " in %s", g.name)
+
+	g.writeFailures(&b, failed)
+
+	for _, r := range pending {
+		fmt.Fprintf(&b, "\n    %s: %s", r.name, strings.TrimPrefix(r.skipReason, "testgroup: "))
+	}
+
+	if _, ok := t.(testingT); ok && (len(failed) > 0 || g.hooks.failed) {
+		fmt.Fprintf(&b, "\nTo rerun the failed tests:\n    go test -run %s", shellQuote(g.rerunPattern(failed)))
+	}
+
+	t.Log(b.String())
+}
+
+// writeFailures writes a line for each failed hook and test to a summary.
+func (g *groupResult) writeFailures(b *strings.Builder, failed []*testResult) {
+	if g.hooks.failed {
+		fmt.Fprintf(b, "\n    %s: %s", g.hooks.failedPhase, summarizeMessage(g.hooks.message))
+	}
+
+	for _, r := range failed {
+		name := r.name
+		if r.failedPhase != "" {
+			name += fmt.Sprintf(" (in %s)", r.failedPhase)
+		}
+
+		if r.attempts > 1 {
+			name += fmt.Sprintf(" (after %d attempts)", r.attempts)
+		}
+
+		fmt.Fprintf(b, "\n    %s: %s", name, summarizeMessage(r.message))
+	}
+}
+
+// rerunPattern returns a go test -run pattern that matches the given tests of the group, or the
+// whole group if one of its hooks failed. A scenario's steps depend on each other, so a scenario
+// always reruns in full.
+func (g *groupResult) rerunPattern(failed []*testResult) string {
+	group := rerun.Pattern(g.name)
+
+	if g.hooks.failed || g.scenario || len(failed) == 0 {
+		return group
+	}
+
+	return strings.Join(append([]string{group}, testPatterns(failed)...), "/")
+}
+
+// testPatterns returns the levels of a go test -run pattern below the group's test that match the
+// given tests.
+func testPatterns(tests []*testResult) []string {
+	serial := []string{}
+	parallel := []string{}
+
+	for _, r := range tests {
+		if r.parallel {
+			parallel = append(parallel, regexp.QuoteMeta(r.name))
+		} else {
+			serial = append(serial, regexp.QuoteMeta(r.name))
+		}
+	}
+
+	parallelParent := regexp.QuoteMeta(RunInParallelParentTestName)
+
+	switch {
+	case len(parallel) == 0:
+		return []string{"^(" + strings.Join(serial, "|") + ")$"}
+	case len(serial) == 0:
+		return []string{"^" + parallelParent + "$", "^(" + strings.Join(parallel, "|") + ")$"}
+	default:
+		// A pattern can't select some parallel tests without also filtering the serial tests'
+		// own subtests, so rerun all of the parallel tests.
+		return []string{"^(" + strings.Join(append(serial, parallelParent), "|") + ")$"}
+	}
+}
+
+// maxSummaryLength is the longest failure message that logSummary will show.
+const maxSummaryLength = 200
+
+// summarizeMessage shortens a failure message to one line. For testify failures, that's the
+// "Error" and "Messages" fields.
+func summarizeMessage(message string) string {
+	fields := testifyFields(message)
+
+	summary := strings.TrimSpace(fields["Error"])
+	if summary == "" {
+		summary = strings.TrimSpace(message)
+		if i := strings.IndexByte(summary, '\n'); i >= 0 {
+			summary = summary[:i]
+		}
+	}
+
+	if messages := strings.TrimSpace(fields["Messages"]); messages != "" {
+		summary += " (" + messages + ")"
+	}
+
+	summary = strings.Join(strings.Fields(summary), " ")
+
+	if summary == "" {
+		return "(no failure message)"
+	}
+
+	if len(summary) > maxSummaryLength {
+		summary = summary[:maxSummaryLength] + "..."
+	}
+
+	return summary
+}
+
+// testifyFields parses a testify failure message, which looks like this:
+//
+//	Error Trace:	file.go:12
+//	Error:      	Not equal:
+//	            	expected: 1
+//	            	actual  : 2
+//
+//	            	Diff:
+//	            	...
+//	Test:       	TestName
+//
+// A field ends at the first blank line, so the diff is left out.
+func testifyFields(message string) map[string]string {
+	fields := map[string]string{}
+	label := ""
+
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimPrefix(line, "\t")
+
+		tab := strings.Index(line, "\t")
+		if tab < 0 {
+			continue
+		}
+
+		if l := strings.TrimSpace(line[:tab]); strings.HasSuffix(l, ":") {
+			label = strings.TrimSuffix(l, ":")
+		}
+
+		value := strings.TrimSpace(line[tab+1:])
+
+		switch {
+		case label == "":
+		case value == "":
+			label = "" // ignore the rest of the field
+		default:
+			fields[label] += " " + value
+		}
+	}
+
+	return fields
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
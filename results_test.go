@@ -0,0 +1,87 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FailureSummary(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		t.Run("Serial", func(t *testing.T) { testgroup.RunSerially(t, &FailureSummary{}) })
+		t.Run("Parallel", func(t *testing.T) { testgroup.RunInParallel(t, &FailureSummary{}) })
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+	assert.ElementsMatch(t, []string{"Serial/Passes", "Parallel/_/Passes"}, subtestNames(t, result, testgrouptest.Passed))
+
+	for group, rerunPattern := range map[string]string{
+		"Serial":   `^Test_FailureSummary$/^Serial$/^(Assertion|Fatal|HookFails)$`,
+		"Parallel": `^Test_FailureSummary$/^Parallel$/^_$/^(Assertion|Fatal|HookFails)$`,
+	} {
+		output := subtest(t, result, group).Output
+
+		assert.Contains(t, output, "testgroup: 3 of 4 tests failed in Test_FailureSummary/"+group)
+		assert.Contains(t, output, "Assertion: Not equal: expected: 1 actual : 2 (one is not two)")
+		assert.Contains(t, output, "Fatal: something went wrong")
+		assert.Contains(t, output, "HookFails (in PreTest): not ready")
+		assert.NotContains(t, output, "Passes:")
+		assert.Contains(t, output, "go test -run '"+rerunPattern+"'")
+	}
+}
+
+type FailureSummary struct{}
+
+func (*FailureSummary) PreTest(t *testgroup.T) {
+	if regexp.MustCompile(`HookFails$`).MatchString(t.Name()) {
+		t.Fatal("not ready")
+	}
+}
+
+func (*FailureSummary) Assertion(t *testgroup.T) { t.Equal(1, 2, "one is not two") }
+func (*FailureSummary) Fatal(t *testgroup.T)     { t.Fatalf("something went %s", "wrong") }
+func (*FailureSummary) HookFails(t *testgroup.T) {}
+func (*FailureSummary) Passes(t *testgroup.T)    {}
+
+//------------------------------------------------------------------------------
+
+func Test_FailureSummary_GroupHook(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunSerially(t, &FailureSummaryGroupHook{})
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "").Outcome)
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Passes").Outcome)
+
+	output := subtest(t, result, "").Output
+
+	assert.Contains(t, output, "testgroup: 0 of 1 tests failed in Test_FailureSummary_GroupHook")
+	assert.Contains(t, output, "PostGroup: Should be true")
+	assert.Contains(t, output, `go test -run '^Test_FailureSummary_GroupHook$'`)
+}
+
+type FailureSummaryGroupHook struct{}
+
+func (*FailureSummaryGroupHook) PostGroup(t *testgroup.T) { t.True(false) }
+func (*FailureSummaryGroupHook) Passes(t *testgroup.T)    {}
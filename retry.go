@@ -0,0 +1,133 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"fmt"
+	"time"
+)
+
+// Retry runs each of the group's test methods again, up to retries times, until it passes. Each
+// attempt is a subtest of the method named attempt-1, attempt-2, and so on, with its own PreTest
+// and PostTest calls. Before each retry, Retry waits for backoff, which doubles after every retry.
+//
+// An attempt that fails and will be retried is reported as skipped, with its failures in its log,
+// so that it doesn't fail the method. Only failures reported through T's own methods, like its
+// assertions and Fatal, can be retried; failures reported through the embedded *testing.T can't be
+// taken back. If the last attempt fails, the method fails.
+//
+// A method that passes after a retry is flaky. WriteMarkdownSummary lists flaky methods, and
+// testgroup-report flags them.
+func Retry(retries int, backoff time.Duration) Option {
+	return func(o *options) { o.retry = retryPolicy{retries: retries, backoff: backoff} }
+}
+
+// RetryForTag is like Retry, but only retries the test methods that have the given tag. A group
+// tags its methods with a Tags method. If more than one retry policy applies to a method, the one
+// with the most retries wins.
+func RetryForTag(tag string, retries int, backoff time.Duration) Option {
+	return func(o *options) {
+		if o.retryForTag == nil {
+			o.retryForTag = map[string]retryPolicy{}
+		}
+
+		o.retryForTag[tag] = retryPolicy{retries: retries, backoff: backoff}
+	}
+}
+
+// retryPolicy is how the Retry and RetryForTag options retry a test method.
+type retryPolicy struct {
+	retries int
+	backoff time.Duration
+}
+
+// retryPolicy returns how to retry a test method.
+func (o *options) retryPolicy(method string, decls *declarations) retryPolicy {
+	policy := o.retry
+
+	for _, tag := range decls.tags[method] {
+		if p, ok := o.retryForTag[tag]; ok && p.retries > policy.retries {
+			policy = p
+		}
+	}
+
+	return policy
+}
+
+// runAttempts runs a test method until it passes or runs out of retries.
+func (r *runner) runAttempts(t *T, method testMethod, result *testResult, policy retryPolicy) {
+	t.tb.Helper()
+
+	attempts := policy.retries + 1
+	backoff := policy.backoff
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(backoff):
+			case <-t.Context().Done():
+			}
+
+			backoff *= 2
+
+			r.skipIfStopped(t)
+		}
+
+		result.attempted(attempt)
+
+		failed, skipped, skipReason := r.runAttempt(t, method, result, attempt, attempts)
+
+		switch {
+		case skipped:
+			t.skipNow(skipReason)
+		case !failed:
+			if attempt > 1 {
+				t.Logf("testgroup: passed on attempt %d of %d", attempt, attempts)
+			}
+
+			return
+		}
+	}
+}
+
+// runAttempt runs one attempt of a test method and returns what happened. The failures of every
+// attempt but the last are intercepted.
+func (r *runner) runAttempt(
+	t *T, method testMethod, result *testResult, attempt, attempts int,
+) (failed, skipped bool, skipReason string) {
+	t.tb.Helper()
+
+	t.Run(fmt.Sprintf("attempt-%d", attempt), func(t *T) {
+		if attempt == attempts {
+			defer func() { failed, skipped = t.Failed(), t.Skipped() }()
+
+			r.runMethod(t, method, result)
+
+			return
+		}
+
+		failed, _, skipped, skipReason = t.intercept(func() { r.runMethod(t, method, result) }).outcome()
+
+		switch {
+		case failed:
+			t.Logf("testgroup: attempt %d of %d failed; retrying", attempt, attempts)
+			t.tb.SkipNow()
+		case skipped:
+			t.tb.SkipNow()
+		}
+	})
+
+	return failed, skipped, skipReason
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Retry(t *testing.T) {
+	g := &Retry{attempts: map[string]int{}}
+	testgroup.RunInParallel(t, g, testgroup.Retry(2, time.Millisecond))
+
+	assert.Equal(t, map[string]int{
+		"FailsOnce":          2,
+		"FailsTwice":         3,
+		"FatalOnce":          2,
+		"FailsOnceInSubtest": 2,
+		"PanicsOnce":         2,
+		"Passes":             1,
+	}, g.attempts)
+
+	assert.Equal(t, 2*12, g.hooks, "each attempt should have its own PreTest and PostTest")
+}
+
+// Retry's methods fail in different ways until their last attempt.
+type Retry struct {
+	mutex    sync.Mutex
+	attempts map[string]int
+	hooks    int
+}
+
+func (g *Retry) PreTest(t *testgroup.T)  { g.hook() }
+func (g *Retry) PostTest(t *testgroup.T) { g.hook() }
+
+func (g *Retry) hook() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.hooks++
+}
+
+// attempt returns which attempt of the method this is.
+func (g *Retry) attempt(t *testgroup.T) int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	name := strings.Split(t.Name(), "/")[2]
+	g.attempts[name]++
+
+	return g.attempts[name]
+}
+
+func (g *Retry) FailsOnce(t *testgroup.T) {
+	t.Equal(2, g.attempt(t))
+}
+
+func (g *Retry) FailsTwice(t *testgroup.T) {
+	t.Equal(3, g.attempt(t))
+}
+
+func (g *Retry) FatalOnce(t *testgroup.T) {
+	if g.attempt(t) == 1 {
+		t.Fatal("flaked")
+	}
+}
+
+func (g *Retry) FailsOnceInSubtest(t *testgroup.T) {
+	attempt := g.attempt(t)
+
+	t.Run("sub", func(t *testgroup.T) {
+		t.Require.Equal(2, attempt)
+	})
+}
+
+func (g *Retry) PanicsOnce(t *testgroup.T) {
+	if g.attempt(t) == 1 {
+		panic("flaked")
+	}
+}
+
+func (g *Retry) Passes(t *testgroup.T) {
+	g.attempt(t)
+}
+
+//------------------------------------------------------------------------------
+
+func Test_RetryForTag(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunSerially(t, &RetryForTag{}, testgroup.RetryForTag("flaky", 1, 0))
+		require.NoError(t, testgroup.WriteMarkdownSummary(os.Stdout))
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	assert.Equal(t, testgrouptest.Skipped, subtest(t, result, "Flaky/attempt-1").Outcome)
+	assert.Contains(t, subtest(t, result, "Flaky/attempt-1").Output, "testgroup: attempt 1 of 2 failed; retrying")
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Flaky/attempt-2").Outcome)
+	assert.Contains(t, subtest(t, result, "Flaky").Output, "testgroup: passed on attempt 2 of 2")
+	assert.Equal(t, testgrouptest.Passed, subtest(t, result, "Flaky").Outcome)
+
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "Broken/attempt-2").Outcome)
+	assert.Contains(t, subtest(t, result, "").Output, "Broken (after 2 attempts): always fails")
+
+	assert.Equal(t, testgrouptest.Failed, subtest(t, result, "NotTagged").Outcome)
+	assert.Nil(t, result.Test(t.Name()+"/NotTagged/attempt-1"))
+
+	assert.Contains(t, result.Output, "Flaky tests, which passed after a retry:\n\n"+
+		"- <code>Test_RetryForTag/Flaky</code>: passed on attempt 2\n")
+}
+
+type RetryForTag struct {
+	flaked bool
+}
+
+func (*RetryForTag) Tags() map[string][]string {
+	return map[string][]string{
+		"Broken": {"flaky"},
+		"Flaky":  {"flaky"},
+	}
+}
+
+func (*RetryForTag) Broken(t *testgroup.T) { t.Fatal("always fails") }
+
+func (g *RetryForTag) Flaky(t *testgroup.T) {
+	if !g.flaked {
+		g.flaked = true
+		t.Fail("flaked")
+	}
+}
+
+func (*RetryForTag) NotTagged(t *testgroup.T) { t.Fatal("not retried") }
@@ -0,0 +1,195 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// RunScenario runs the test methods of a group as the steps of one workflow, like creating,
+// updating, and deleting a resource. The steps run sequentially, and a group says which steps
+// depend on which with a DependsOn method:
+//
+//	func (*MyScenario) DependsOn() map[string][]string {
+//		return map[string][]string{
+//			"Update": {"Create"},
+//			"Delete": {"Update"},
+//		}
+//	}
+//
+// Each step runs after the steps it depends on, and otherwise in lexicographic order. If a step
+// fails or is skipped, the steps that depend on it, directly or not, are skipped.
+//
+// RunSerially and RunInParallel ignore the DependsOn method.
+func RunScenario(t *testing.T, group interface{}, opts ...Option) {
+	t.Helper()
+	runScenario(testingT{t}, group, opts...)
+}
+
+// RunScenario runs the test methods of a group as the steps of one workflow.
+func (t *T) RunScenario(group interface{}, opts ...Option) {
+	t.tb.Helper()
+	runScenario(t.tb, group, opts...)
+}
+
+func runScenario(t TB, group interface{}, opts ...Option) {
+	t.Helper()
+
+	r := newRunner(t, false, group, newOptions(opts))
+	r.scenario = true
+	r.result.scenario = true
+	r.run(t)
+}
+
+// stepOrder sorts a scenario's steps so that each one comes after the steps it depends on. methods
+// must be in lexicographic order, and dependsOn must not have cycles.
+func stepOrder(methods []testMethod, dependsOn map[string][]string) []testMethod {
+	ordered := make([]testMethod, 0, len(methods))
+	done := map[string]bool{}
+
+	for len(ordered) < len(methods) {
+		for _, m := range methods {
+			if !done[m.Name] && allDone(dependsOn[m.Name], done) {
+				ordered = append(ordered, m)
+				done[m.Name] = true
+
+				break
+			}
+		}
+	}
+
+	return ordered
+}
+
+func allDone(steps []string, done map[string]bool) bool {
+	for _, step := range steps {
+		if !done[step] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// skipIfDependencyFailed skips a step if a step it depends on failed or was skipped.
+func (r *runner) skipIfDependencyFailed(t *T, step string) {
+	t.tb.Helper()
+
+	for _, dependency := range r.decls.dependsOn[step] {
+		result := r.result.test(dependency)
+		if result == nil {
+			continue
+		}
+
+		result.mutex.Lock()
+		failed, skipped := result.failed, result.skipped
+		result.mutex.Unlock()
+
+		switch {
+		case failed:
+			t.Skipf("testgroup: skipped because %s failed", dependency)
+		case skipped:
+			t.Skipf("testgroup: skipped because %s was skipped", dependency)
+		}
+	}
+}
+
+// requireDependencies fails the test if a DependsOn declaration mentions a step that isn't a test
+// method or has a cycle.
+func requireDependencies(
+	t TB, group interface{}, methods []testMethod, dependsOn map[string][]string,
+) {
+	t.Helper()
+
+	isMethod := map[string]bool{}
+	for _, m := range methods {
+		isMethod[m.Name] = true
+	}
+
+	steps := make([]string, 0, len(dependsOn))
+	for step := range dependsOn {
+		steps = append(steps, step)
+	}
+
+	sort.Strings(steps)
+
+	for _, step := range steps {
+		for _, dependency := range dependsOn[step] {
+			if !isMethod[dependency] {
+				t.Errorf("testgroup: %T.DependsOn says %q depends on %q, which is not a test method.",
+					group, step, dependency)
+			}
+		}
+	}
+
+	if cycle := findCycle(steps, dependsOn); cycle != nil {
+		t.Errorf("testgroup: %T.DependsOn has a cycle, where each step depends on the next: %s.",
+			group, strings.Join(cycle, " -> "))
+	}
+}
+
+// findCycle returns the steps of a cycle in dependsOn, starting and ending with the same step, or
+// nil if there are no cycles.
+func findCycle(steps []string, dependsOn map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := map[string]int{}
+	path := []string{}
+
+	var visit func(step string) []string
+
+	visit = func(step string) []string {
+		switch state[step] {
+		case visiting:
+			for i, s := range path {
+				if s == step {
+					return append(append([]string{}, path[i:]...), step)
+				}
+			}
+		case visited:
+			return nil
+		}
+
+		state[step] = visiting
+		path = append(path, step)
+
+		for _, dependency := range dependsOn[step] {
+			if cycle := visit(dependency); cycle != nil {
+				return cycle
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[step] = visited
+
+		return nil
+	}
+
+	for _, step := range steps {
+		if state[step] == unvisited {
+			if cycle := visit(step); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Scenario(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunScenario(t, &Scenario{})
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	steps := []string{}
+
+	for _, test := range result.Tests {
+		if strings.HasPrefix(test.Name, t.Name()+"/") {
+			steps = append(steps, strings.TrimPrefix(test.Name, t.Name()+"/"))
+		}
+	}
+
+	assert.Equal(t, []string{"Audit", "Create", "List", "Update", "Delete", "Archive"}, steps)
+
+	assert.Equal(t, []string{"Audit", "Create", "List"}, subtestNames(t, result, testgrouptest.Passed))
+	assert.Equal(t, []string{"Update"}, subtestNames(t, result, testgrouptest.Failed))
+	assert.Equal(t, []string{"Delete", "Archive"}, subtestNames(t, result, testgrouptest.Skipped))
+	assert.Contains(t, subtest(t, result, "Delete").Output, "testgroup: skipped because Update failed")
+	assert.Contains(t, subtest(t, result, "Archive").Output, "testgroup: skipped because Delete was skipped")
+	assert.NotContains(t, result.Output, "should not run")
+	assert.Contains(t, subtest(t, result, "").Output, "go test -run '^Test_Scenario$'",
+		"scenarios should rerun in full")
+}
+
+// Scenario shares state between its steps, which don't run in lexicographic order.
+type Scenario struct {
+	items []string
+}
+
+func (*Scenario) DependsOn() map[string][]string {
+	return map[string][]string{
+		"Archive": {"Delete"},
+		"Delete":  {"Update", "Create"},
+		"List":    {"Create"},
+		"Update":  {"Create"},
+	}
+}
+
+func (s *Scenario) Audit(t *testgroup.T)  { t.Empty(s.items) }
+func (s *Scenario) Create(t *testgroup.T) { s.items = append(s.items, "item") }
+func (s *Scenario) List(t *testgroup.T)   { t.Len(s.items, 1) }
+func (s *Scenario) Update(t *testgroup.T) { t.Equal("updated", s.items[0]) }
+
+func (s *Scenario) Delete(t *testgroup.T)  { t.Fatal("should not run") }
+func (s *Scenario) Archive(t *testgroup.T) { t.Fatal("should not run") }
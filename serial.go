@@ -0,0 +1,44 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+// A SerialPhase says when RunInParallel runs a test method that must not run at the same time as
+// the group's other methods. A group lists those methods with a Serial method:
+//
+//	func (*MyGroup) Serial() map[string]testgroup.SerialPhase {
+//		return map[string]testgroup.SerialPhase{
+//			"CreateSchema": testgroup.BeforeParallel,
+//			"DropSchema":   testgroup.AfterParallel,
+//		}
+//	}
+//
+// RunInParallel runs the BeforeParallel methods one at a time, then the rest of the methods in
+// parallel, then the AfterParallel methods one at a time, all between the same PreGroup and
+// PostGroup calls. Within each serial phase, methods run in lexicographic order. Serial methods
+// are subtests of the group's test itself rather than of RunInParallelParentTestName.
+//
+// RunSerially ignores the Serial method, since it runs every method serially anyway.
+type SerialPhase int
+
+const (
+	// parallelPhase is the phase of the methods that a Serial method doesn't mention.
+	parallelPhase SerialPhase = iota
+
+	// BeforeParallel methods run before the group's parallel methods start.
+	BeforeParallel
+
+	// AfterParallel methods run after the group's parallel methods finish.
+	AfterParallel
+)
@@ -0,0 +1,115 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SerialPhases(t *testing.T) {
+	g := &SerialPhases{}
+	testgroup.RunInParallel(t, g)
+
+	parallel := t.Name() + "/" + testgroup.RunInParallelParentTestName
+
+	assert.Equal(t, t.Name()+" PreGroup", g.calls[0])
+	assert.Equal(t, t.Name()+"/CreateSchema", g.calls[1])
+	assert.Equal(t, t.Name()+"/LoadData", g.calls[2])
+	assert.ElementsMatch(t,
+		[]string{parallel + "/QueryA", parallel + "/QueryB", parallel + "/QueryC"},
+		g.calls[3:6])
+	assert.Equal(t, t.Name()+"/DropSchema", g.calls[6])
+	assert.Equal(t, t.Name()+" PostGroup", g.calls[7])
+	assert.Len(t, g.calls, 8)
+}
+
+// SerialPhases records when each of its methods finishes.
+type SerialPhases struct {
+	mutex sync.Mutex
+	calls []string
+}
+
+func (g *SerialPhases) Serial() map[string]testgroup.SerialPhase {
+	return map[string]testgroup.SerialPhase{
+		"CreateSchema": testgroup.BeforeParallel,
+		"DropSchema":   testgroup.AfterParallel,
+		"LoadData":     testgroup.BeforeParallel,
+	}
+}
+
+func (g *SerialPhases) called(name string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.calls = append(g.calls, name)
+}
+
+func (g *SerialPhases) PreGroup(t *testgroup.T)  { g.called(t.Name() + " PreGroup") }
+func (g *SerialPhases) PostGroup(t *testgroup.T) { g.called(t.Name() + " PostGroup") }
+
+func (g *SerialPhases) work(t *testgroup.T) {
+	time.Sleep(10 * time.Millisecond)
+	g.called(t.Name())
+}
+
+func (g *SerialPhases) CreateSchema(t *testgroup.T) { g.work(t) }
+func (g *SerialPhases) DropSchema(t *testgroup.T)   { g.work(t) }
+func (g *SerialPhases) LoadData(t *testgroup.T)     { g.work(t) }
+func (g *SerialPhases) QueryA(t *testgroup.T)       { g.work(t) }
+func (g *SerialPhases) QueryB(t *testgroup.T)       { g.work(t) }
+func (g *SerialPhases) QueryC(t *testgroup.T)       { g.work(t) }
+
+func Test_SerialPhases_OnlySerialMethods(t *testing.T) {
+	g := &OnlySerialMethods{}
+	testgroup.RunInParallel(t, g)
+
+	assert.Equal(t, []string{t.Name() + "/Before", t.Name() + "/After"}, g.calls)
+}
+
+type OnlySerialMethods struct {
+	calls []string
+}
+
+func (g *OnlySerialMethods) Serial() map[string]testgroup.SerialPhase {
+	return map[string]testgroup.SerialPhase{
+		"After":  testgroup.AfterParallel,
+		"Before": testgroup.BeforeParallel,
+	}
+}
+
+func (g *OnlySerialMethods) After(t *testgroup.T)  { g.calls = append(g.calls, t.Name()) }
+func (g *OnlySerialMethods) Before(t *testgroup.T) { g.calls = append(g.calls, t.Name()) }
+
+func Test_DeclarationNamedTests(t *testing.T) {
+	g := &DeclarationNamedTests{}
+	testgroup.RunSerially(t, g)
+
+	assert.Equal(t, []string{t.Name() + "/Pending", t.Name() + "/Serial", t.Name() + "/Tags"}, g.calls)
+}
+
+// DeclarationNamedTests has test methods with the names of declaration methods, which groups could
+// have before those declarations existed.
+type DeclarationNamedTests struct {
+	calls []string
+}
+
+func (g *DeclarationNamedTests) Pending(t *testgroup.T) { g.calls = append(g.calls, t.Name()) }
+func (g *DeclarationNamedTests) Serial(t *testgroup.T)  { g.calls = append(g.calls, t.Name()) }
+func (g *DeclarationNamedTests) Tags(t *testgroup.T)    { g.calls = append(g.calls, t.Name()) }
@@ -0,0 +1,78 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SkipGroup(t *testing.T) {
+	for _, reason := range []string{"", "needs docker"} {
+		g := &SkipGroup{reason: reason}
+		skipped := false
+
+		t.Run("Reason="+reason, func(t *testing.T) {
+			t.Cleanup(func() { skipped = t.Skipped() })
+			testgroup.RunInParallel(t, g)
+		})
+
+		if reason == "" {
+			assert.False(t, skipped)
+			assert.Equal(t, []string{"SkipGroup", "PreGroup", "Test", "PostGroup"}, g.calls)
+		} else {
+			assert.True(t, skipped)
+			assert.Equal(t, []string{"SkipGroup"}, g.calls)
+		}
+	}
+}
+
+type SkipGroup struct {
+	reason string
+	calls  []string
+}
+
+func (g *SkipGroup) SkipGroup(t *testgroup.T) string {
+	g.calls = append(g.calls, "SkipGroup")
+	return g.reason
+}
+
+func (g *SkipGroup) PreGroup(t *testgroup.T)  { g.calls = append(g.calls, "PreGroup") }
+func (g *SkipGroup) PostGroup(t *testgroup.T) { g.calls = append(g.calls, "PostGroup") }
+func (g *SkipGroup) Test(t *testgroup.T)      { g.calls = append(g.calls, "Test") }
+
+func Test_SkipGroup_MarkdownSummary(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		t.Run("Group", func(t *testing.T) { testgroup.RunSerially(t, &SkipGroup{reason: "needs <docker>"}) })
+		require.NoError(t, testgroup.WriteMarkdownSummary(os.Stdout))
+
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+	require.Equal(t, 0, result.ExitCode, "combined output:\n%s", result.Output)
+	assert.Equal(t, testgrouptest.Skipped, subtest(t, result, "Group").Outcome)
+
+	output := result.Output
+	assert.Contains(t, output,
+		"| :fast_forward: | <code>Test_SkipGroup_MarkdownSummary/Group</code> | serial | 0 | 0 | 0 |")
+	assert.Contains(t, output,
+		"- <code>Test_SkipGroup_MarkdownSummary/Group</code>: testgroup: skipping the group: needs &lt;docker&gt;")
+}
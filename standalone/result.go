@@ -0,0 +1,129 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standalone
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bloomberg/go-testgroup"
+)
+
+// Outcome is how a test finished.
+type Outcome string
+
+// The outcomes of a test, which are the same as the actions that go test -json reports.
+const (
+	Pass Outcome = "pass"
+	Fail Outcome = "fail"
+	Skip Outcome = "skip"
+)
+
+// Result is what happened when Run ran some groups.
+type Result struct {
+	Outcome Outcome `json:"outcome"` // Fail if any group failed, and Pass otherwise
+	Elapsed float64 `json:"elapsed"` // in seconds
+	Groups  []*Test `json:"groups"`  // in the order that they ran
+}
+
+// Test is what happened to a group, one of its test methods, or one of their subtests.
+type Test struct {
+	Name     string   `json:"name"` // the full name, like "Group/Method"
+	Outcome  Outcome  `json:"outcome"`
+	Elapsed  float64  `json:"elapsed"`          // in seconds
+	Output   []string `json:"output,omitempty"` // the messages that the test logged
+	Subtests []*Test  `json:"subtests,omitempty"`
+}
+
+// Failed reports whether any group failed.
+func (r *Result) Failed() bool {
+	return r.Outcome == Fail
+}
+
+// newTest returns what happened to a recorded test. stopped is why Run stopped waiting for it, if
+// it did.
+func newTest(rec *testgroup.Recorder, stopped error) *Test {
+	test := &Test{
+		Name:    rec.Name(),
+		Outcome: Pass,
+		Elapsed: rec.Elapsed().Seconds(),
+		Output:  rec.Logs(),
+	}
+
+	for _, sub := range rec.Subtests() {
+		test.Subtests = append(test.Subtests, newTest(sub, stopped))
+	}
+
+	select {
+	case <-rec.Done():
+		switch {
+		case rec.Failed():
+			test.Outcome = Fail
+		case rec.Skipped():
+			test.Outcome = Skip
+		}
+	default:
+		test.Outcome = Fail
+		test.Output = append(test.Output, fmt.Sprintf("standalone: the test was still running: %v", stopped))
+	}
+
+	return test
+}
+
+// WriteJSON writes the result as indented JSON.
+func (r *Result) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteText writes the result like go test does: a line for each test that failed, or for every
+// test if verbose is true, followed by the test's output, and then PASS or FAIL.
+func (r *Result) WriteText(w io.Writer, verbose bool) error {
+	var b strings.Builder
+
+	for _, g := range r.Groups {
+		writeTest(&b, g, 0, verbose)
+	}
+
+	fmt.Fprintf(&b, "%s (%.2fs)\n", strings.ToUpper(string(r.Outcome)), r.Elapsed)
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func writeTest(b *strings.Builder, t *Test, depth int, verbose bool) {
+	if !verbose && t.Outcome != Fail {
+		return
+	}
+
+	indent := strings.Repeat("    ", depth)
+
+	fmt.Fprintf(b, "%s--- %s: %s (%.2fs)\n", indent, strings.ToUpper(string(t.Outcome)), t.Name, t.Elapsed)
+
+	for _, message := range t.Output {
+		for _, line := range strings.Split(strings.Trim(message, "\n"), "\n") {
+			fmt.Fprintf(b, "%s    %s\n", indent, line)
+		}
+	}
+
+	for _, sub := range t.Subtests {
+		writeTest(b, sub, depth+1, verbose)
+	}
+}
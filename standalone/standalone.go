@@ -0,0 +1,244 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package standalone runs test groups outside go test, so that a program can reuse them, for
+// example as smoke checks after a deployment:
+//
+//	result, err := standalone.Run(ctx, standalone.Config{Run: *run, Timeout: time.Minute},
+//		standalone.Group{Name: "Database", Group: &DatabaseChecks{}},
+//		standalone.Group{Name: "API", Group: &APIChecks{}, Parallel: true},
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	result.WriteText(os.Stdout, *verbose)
+//
+//	if result.Failed() {
+//		os.Exit(1)
+//	}
+//
+// The groups run on testgroup.Recorders, one at a time.
+package standalone
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+)
+
+// stopWait is how long Run waits for a group to stop after the timeout expires or ctx is canceled.
+const stopWait = time.Second
+
+// Group is a test group for Run to run.
+type Group struct {
+	// Name is the name of the group's top-level test. It defaults to the name of the group's type.
+	Name string
+
+	// Group is the test group, like &MyChecks{}.
+	Group interface{}
+
+	// Parallel runs the group like testgroup.RunInParallel instead of testgroup.RunSerially.
+	Parallel bool
+
+	// Options are passed to testgroup.
+	Options []testgroup.Option
+}
+
+// Config changes how Run runs groups.
+type Config struct {
+	// Run, if not empty, only runs the tests whose names match it, like the -run flag of go test.
+	// It's split by slashes into regular expressions that each match one level of the tests'
+	// names, like "Database/^Query" to run the methods of the Database group that start with
+	// Query. The parent test of the methods of a Parallel group is left out of the names that it
+	// matches.
+	Run string
+
+	// Skip, if not empty, doesn't run the tests whose names match it, like the -skip flag of go
+	// test. It's split by slashes like Run.
+	Skip string
+
+	// Timeout, if not zero, stops running the groups after the given time. The contexts of the
+	// running tests are canceled, and tests that are still running a moment later fail.
+	Timeout time.Duration
+}
+
+// Run runs the groups in order and returns what happened. It returns an error if config isn't
+// valid.
+//
+// When ctx is canceled, or config.Timeout expires, the contexts of the running tests are canceled,
+// and the groups that haven't started yet are skipped, which fails the result. Tests that don't
+// stop shortly after their context is canceled are reported as failed, and may keep running in
+// the background.
+func Run(ctx context.Context, config Config, groups ...Group) (*Result, error) {
+	f, err := newFilter(config.Run, config.Skip)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	result := &Result{Outcome: Pass}
+
+	for _, g := range groups {
+		name := g.Name
+		if name == "" {
+			name = reflect.Indirect(reflect.ValueOf(g.Group)).Type().Name()
+		}
+
+		if !f.matches(name) {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			result.Groups = append(result.Groups, &Test{
+				Name:    name,
+				Outcome: Skip,
+				Output:  []string{fmt.Sprintf("standalone: didn't run the group: %v", err)},
+			})
+			result.Outcome = Fail
+
+			continue
+		}
+
+		test := runGroup(ctx, f, name, g)
+		result.Groups = append(result.Groups, test)
+
+		if test.Outcome == Fail {
+			result.Outcome = Fail
+		}
+	}
+
+	result.Elapsed = time.Since(start).Seconds()
+
+	return result, nil
+}
+
+// runGroup runs one group.
+func runGroup(ctx context.Context, f *filter, name string, g Group) *Test {
+	rec := testgroup.StartRecording(ctx, name, func(t testgroup.TB) {
+		t = filteredTB{t.(*testgroup.Recorder), f}
+
+		if g.Parallel {
+			testgroup.RunInParallelOn(t, g.Group, g.Options...)
+		} else {
+			testgroup.RunSeriallyOn(t, g.Group, g.Options...)
+		}
+	})
+
+	select {
+	case <-rec.Done():
+	case <-ctx.Done():
+		select {
+		case <-rec.Done():
+		case <-time.After(stopWait):
+		}
+	}
+
+	return newTest(rec, ctx.Err())
+}
+
+// filteredTB is a Recorder that only runs the subtests that match a filter.
+type filteredTB struct {
+	*testgroup.Recorder
+	filter *filter
+}
+
+func (t filteredTB) Run(name string, f func(t testgroup.TB)) bool {
+	if !t.filter.matches(t.Name() + "/" + name) {
+		return true
+	}
+
+	return t.Recorder.Run(name, func(sub testgroup.TB) {
+		f(filteredTB{sub.(*testgroup.Recorder), t.filter})
+	})
+}
+
+// filter selects tests by name for Config.Run and Config.Skip.
+type filter struct {
+	run  []*regexp.Regexp
+	skip []*regexp.Regexp
+}
+
+func newFilter(run, skip string) (*filter, error) {
+	f := &filter{}
+
+	var err error
+
+	if f.run, err = compilePattern("Run", run); err != nil {
+		return nil, err
+	}
+
+	if f.skip, err = compilePattern("Skip", skip); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func compilePattern(field, pattern string) ([]*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+
+	var levels []*regexp.Regexp
+
+	for _, level := range strings.Split(pattern, "/") {
+		re, err := regexp.Compile(level)
+		if err != nil {
+			return nil, fmt.Errorf("standalone: invalid Config.%s: %w", field, err)
+		}
+
+		levels = append(levels, re)
+	}
+
+	return levels, nil
+}
+
+// matches reports whether the test with the given full name should run.
+func (f *filter) matches(name string) bool {
+	levels := strings.Split(name, "/")
+	if len(levels) > 1 && levels[1] == testgroup.RunInParallelParentTestName {
+		levels = append(levels[:1], levels[2:]...)
+	}
+
+	for i, level := range levels {
+		if i < len(f.run) && !f.run[i].MatchString(level) {
+			return false
+		}
+	}
+
+	if len(f.skip) == 0 || len(levels) < len(f.skip) {
+		return true
+	}
+
+	for i, re := range f.skip {
+		if !re.MatchString(levels[i]) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standalone_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/standalone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Run(t *testing.T) {
+	result, err := standalone.Run(context.Background(), standalone.Config{},
+		standalone.Group{Group: &Checks{}},
+		standalone.Group{Name: "Parallel", Group: &Checks{}, Parallel: true},
+	)
+	require.NoError(t, err)
+
+	assert.True(t, result.Failed())
+	require.Len(t, result.Groups, 2)
+
+	serial := result.Groups[0]
+	assert.Equal(t, "Checks", serial.Name)
+	assert.Equal(t, standalone.Fail, serial.Outcome)
+	assert.Equal(t, []string{"Checks/Fails", "Checks/Passes", "Checks/Skips"}, names(serial.Subtests))
+	assert.Equal(t, standalone.Fail, serial.Subtests[0].Outcome)
+	assert.Equal(t, []string{"failing on purpose"}, serial.Subtests[0].Output)
+	assert.Equal(t, standalone.Pass, serial.Subtests[1].Outcome)
+	assert.Equal(t, standalone.Skip, serial.Subtests[2].Outcome)
+	assert.Equal(t, []string{"testgroup: 1 of 3 tests failed and 1 skipped in Checks\n    Fails: failing on purpose"},
+		serial.Output)
+
+	parallel := result.Groups[1]
+	require.Len(t, parallel.Subtests, 1)
+	assert.Equal(t, "Parallel/_", parallel.Subtests[0].Name)
+	assert.ElementsMatch(t,
+		[]string{"Parallel/_/Fails", "Parallel/_/Passes", "Parallel/_/Skips"},
+		names(parallel.Subtests[0].Subtests))
+
+	var text bytes.Buffer
+	require.NoError(t, result.WriteText(&text, false))
+	assert.Contains(t, text.String(), "--- FAIL: Checks (")
+	assert.Contains(t, text.String(), "    --- FAIL: Checks/Fails (")
+	assert.Contains(t, text.String(), "        failing on purpose\n")
+	assert.NotContains(t, text.String(), "Checks/Passes")
+	assert.Contains(t, text.String(), "\nFAIL (")
+
+	text.Reset()
+	require.NoError(t, result.WriteText(&text, true))
+	assert.Contains(t, text.String(), "    --- PASS: Checks/Passes (")
+	assert.Contains(t, text.String(), "    --- SKIP: Checks/Skips (")
+	assert.Contains(t, text.String(), "        skipping on purpose\n")
+}
+
+func Test_Run_JSON(t *testing.T) {
+	result, err := standalone.Run(context.Background(), standalone.Config{Run: "/Passes"},
+		standalone.Group{Group: &Checks{}})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, result.WriteJSON(&out))
+
+	var decoded standalone.Result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+
+	assert.Equal(t, standalone.Pass, decoded.Outcome)
+	require.Len(t, decoded.Groups, 1)
+	assert.Equal(t, "Checks", decoded.Groups[0].Name)
+	require.Len(t, decoded.Groups[0].Subtests, 1)
+	assert.Equal(t, "Checks/Passes", decoded.Groups[0].Subtests[0].Name)
+	assert.Equal(t, standalone.Pass, decoded.Groups[0].Subtests[0].Outcome)
+	assert.Contains(t, out.String(), `"outcome": "pass"`)
+}
+
+func Test_Run_Filters(t *testing.T) {
+	run := func(config standalone.Config) [][]string {
+		result, err := standalone.Run(context.Background(), config,
+			standalone.Group{Group: &Checks{}},
+			standalone.Group{Name: "Parallel", Group: &Checks{}, Parallel: true},
+		)
+		require.NoError(t, err)
+
+		var tests [][]string
+
+		for _, g := range result.Groups {
+			methods := g.Subtests
+			if len(methods) == 1 && methods[0].Name == g.Name+"/"+testgroup.RunInParallelParentTestName {
+				methods = methods[0].Subtests
+			}
+
+			tests = append(tests, append([]string{g.Name}, names(methods)...))
+		}
+
+		return tests
+	}
+
+	assert.Equal(t, [][]string{{"Checks", "Checks/Passes"}}, run(standalone.Config{Run: "^Checks$/Pass"}))
+	assert.Equal(t,
+		[][]string{{"Checks", "Checks/Passes"}, {"Parallel", "Parallel/_/Passes"}},
+		run(standalone.Config{Run: "/Pass"}))
+	assert.Equal(t,
+		[][]string{{"Checks", "Checks/Passes", "Checks/Skips"}},
+		run(standalone.Config{Run: "Checks", Skip: "Checks/Fails"}))
+	assert.Equal(t,
+		[][]string{{"Checks", "Checks/Fails", "Checks/Passes", "Checks/Skips"}},
+		run(standalone.Config{Skip: "Parallel"}))
+
+	_, err := standalone.Run(context.Background(), standalone.Config{Run: "("})
+	assert.Error(t, err)
+}
+
+func Test_Run_Timeout(t *testing.T) {
+	result, err := standalone.Run(context.Background(), standalone.Config{Timeout: 100 * time.Millisecond},
+		standalone.Group{Group: &SlowChecks{}},
+		standalone.Group{Group: &Checks{}},
+	)
+	require.NoError(t, err)
+
+	assert.True(t, result.Failed())
+	require.Len(t, result.Groups, 2)
+
+	slow := result.Groups[0]
+	assert.Equal(t, standalone.Fail, slow.Outcome)
+	require.Len(t, slow.Subtests, 2)
+	assert.Equal(t, standalone.Fail, slow.Subtests[0].Outcome)
+	assert.Equal(t, []string{"stopped: context deadline exceeded"}, slow.Subtests[0].Output)
+	assert.Equal(t, standalone.Fail, slow.Subtests[1].Outcome)
+	assert.Equal(t,
+		[]string{"standalone: the test was still running: context deadline exceeded"},
+		slow.Subtests[1].Output)
+
+	skipped := result.Groups[1]
+	assert.Equal(t, standalone.Skip, skipped.Outcome)
+	assert.Equal(t, []string{"standalone: didn't run the group: context deadline exceeded"}, skipped.Output)
+}
+
+func Test_Run_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := standalone.Run(ctx, standalone.Config{}, standalone.Group{Group: &Checks{}})
+	require.NoError(t, err)
+
+	assert.True(t, result.Failed(), "groups that didn't run should fail the result")
+	require.Len(t, result.Groups, 1)
+	assert.Equal(t, standalone.Skip, result.Groups[0].Outcome)
+	assert.Equal(t, []string{"standalone: didn't run the group: context canceled"}, result.Groups[0].Output)
+}
+
+func names(tests []*standalone.Test) []string {
+	names := []string{}
+	for _, t := range tests {
+		names = append(names, t.Name)
+	}
+
+	return names
+}
+
+type Checks struct{}
+
+func (*Checks) Fails(t *testgroup.T) {
+	t.Fatal("failing on purpose")
+}
+
+func (*Checks) Passes(t *testgroup.T) {
+	t.True(true)
+}
+
+func (*Checks) Skips(t *testgroup.T) {
+	t.Skip("skipping on purpose")
+}
+
+type SlowChecks struct{}
+
+func (*SlowChecks) A_WaitsForContext(t *testgroup.T) {
+	<-t.Context().Done()
+	t.Fatalf("stopped: %v", t.Context().Err())
+}
+
+func (*SlowChecks) B_IgnoresContext(t *testgroup.T) {
+	time.Sleep(time.Minute)
+}
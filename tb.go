@@ -0,0 +1,87 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"context"
+	"flag"
+	"testing"
+)
+
+// TB is what testgroup runs a group on: testing.TB, plus the Parallel and Run methods of
+// *testing.T, with subtests that are TBs too. RunSerially and RunInParallel run groups on a
+// *testing.T, and RunSeriallyOn and RunInParallelOn run them on any TB, like a Recorder.
+//
+// Since testing.TB has an unexported method, other implementations must embed a testing.TB. If a
+// TB has a Context method, like a Recorder, the Context of the T that testgroup passes to the
+// group's methods is derived from it.
+type TB interface {
+	testing.TB
+
+	// Parallel signals that the test runs in parallel with its parallel siblings, like
+	// testing.T.Parallel.
+	Parallel()
+
+	// Run runs f as a subtest of the test, like testing.T.Run.
+	Run(name string, f func(t TB)) bool
+}
+
+// RunSeriallyOn is just like RunSerially, but runs the group on any TB.
+func RunSeriallyOn(t TB, group interface{}, opts ...Option) {
+	t.Helper()
+	run(t, false, group, newOptions(opts))
+}
+
+// RunInParallelOn is just like RunInParallel, but runs the group on any TB.
+func RunInParallelOn(t TB, group interface{}, opts ...Option) {
+	t.Helper()
+	run(t, true, group, newOptions(opts))
+}
+
+// testingT is the TB for a *testing.T.
+type testingT struct {
+	*testing.T
+}
+
+func (t testingT) Run(name string, f func(t TB)) bool {
+	t.T.Helper()
+	return t.T.Run(name, func(t *testing.T) { f(testingT{t}) })
+}
+
+// testingTOf returns the *testing.T that T embeds for a TB. When the TB isn't a *testing.T, it's a
+// placeholder that isn't connected to any test, so that calls to its Helper method, which can't be
+// forwarded to the TB without marking the wrong function as a helper, do nothing.
+func testingTOf(t TB) *testing.T {
+	if t, ok := t.(testingT); ok {
+		return t.T
+	}
+
+	return &testing.T{}
+}
+
+// testContext returns the context that the contexts of a test's Ts are derived from.
+func testContext(t TB) context.Context {
+	if c, ok := t.(interface{ Context() context.Context }); ok {
+		return c.Context()
+	}
+
+	return context.Background()
+}
+
+// verbose reports whether go test's -v flag is set. It's false in programs that run groups on a
+// Recorder without go test, where testing.Verbose would panic.
+func verbose() bool {
+	return flag.Lookup("test.v") != nil && testing.Verbose()
+}
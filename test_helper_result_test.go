@@ -0,0 +1,56 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/require"
+)
+
+// subtest returns what happened to one of the current test's subtests in a result from
+// testgrouptest.Rerun, given its name relative to the current test, or the current test itself
+// if name is empty. It fails the current test if the subtest didn't finish.
+func subtest(t *testing.T, result *testgrouptest.Result, name string) *testgrouptest.Test {
+	t.Helper()
+
+	fullName := t.Name()
+	if name != "" {
+		fullName += "/" + name
+	}
+
+	test := result.Test(fullName)
+	require.NotNil(t, test, "%s didn't finish", fullName)
+
+	return test
+}
+
+// subtestNames returns the names, relative to the current test, of its subtests in a result from
+// testgrouptest.Rerun that had the given outcome, in the order that they finished.
+func subtestNames(t *testing.T, result *testgrouptest.Result, outcome testgrouptest.Outcome) []string {
+	t.Helper()
+
+	names := []string{}
+
+	for _, name := range result.Names(outcome) {
+		if strings.HasPrefix(name, t.Name()+"/") {
+			names = append(names, strings.TrimPrefix(name, t.Name()+"/"))
+		}
+	}
+
+	return names
+}
@@ -23,9 +23,13 @@
 package testgroup
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,10 +37,39 @@ import (
 
 // T is a type passed to each test function. It is mainly concerned with test state, and it embeds
 // and contains other types for convenience.
+//
+// When a group runs on a TB that isn't a *testing.T, like a Recorder, the embedded *testing.T isn't
+// connected to the test, so use T's own methods instead.
 type T struct {
 	*testing.T
 	*assert.Assertions
 	Require *require.Assertions
+
+	output *output     // nil unless the BufferOutput option is in effect
+	result *testResult // where failures are recorded for the group's failure summary
+	tracer *tracer     // nil unless the Trace option is in effect
+	slot   int         // the trace thread that this test is running in
+	ctx    context.Context
+
+	interception *interception      // nil unless testgroup is intercepting the test's failures
+	goroutines   *goroutineSnapshot // nil unless the DetectGoroutineLeaks option is in effect
+	environment  *environmentGuard
+	undo         []func() error // undoes the changes made by Setenv and Chdir
+
+	tb TB // the test, which is also the embedded *testing.T if it's a *testing.T
+}
+
+func newT(t TB, bufferOutput bool, result *testResult) *T {
+	newT := &T{T: testingTOf(t), result: result, tb: t}
+
+	if bufferOutput {
+		newT.output = newOutput(t)
+	}
+
+	newT.Assertions = assert.New(assertionT{t, newT})
+	newT.Require = require.New(assertionT{t, newT})
+
+	return newT
 }
 
 // RunInParallelParentTestName is the name of the parent test of RunInParallel subtests.
@@ -64,113 +97,564 @@ type T struct {
 var RunInParallelParentTestName = "_"
 
 // RunSerially runs the test methods of a group sequentially in lexicographic order.
-func RunSerially(t *testing.T, group interface{}) {
+func RunSerially(t *testing.T, group interface{}, opts ...Option) {
 	t.Helper()
-	run(t, false, group)
+	run(testingT{t}, false, group, newOptions(opts))
 }
 
 // RunInParallel runs the test methods of a group simultaneously and waits for all of them to
 // complete before returning.
-func RunInParallel(t *testing.T, group interface{}) {
+//
+// Options that check state shared by the whole process after each test method, like
+// DetectFileLeaks, GuardEnvironment, and GuardGlobals, can't tell which of the methods running in
+// parallel changed it, so those methods aren't checked one by one. Their changes fail the group
+// instead. The methods that a Serial method runs before or after the others are still checked.
+func RunInParallel(t *testing.T, group interface{}, opts ...Option) {
 	t.Helper()
-	run(t, true, group)
+	run(testingT{t}, true, group, newOptions(opts))
 }
 
 // Run is just like testing.T.Run, but the argument to f is a *testgroup.T instead of a *testing.T.
 func (t *T) Run(name string, testFunc func(t *T)) {
-	t.T.Helper()
-	t.T.Run(name, func(t *testing.T) {
-		funcT := &T{
-			T:          t,
-			Assertions: assert.New(t),
-			Require:    require.New(t),
+	t.tb.Helper()
+
+	parent := t
+
+	t.tb.Run(name, func(t TB) {
+		funcT := newT(t, parent.output != nil, parent.result)
+		if funcT.output != nil {
+			defer funcT.output.flush()
 		}
 
-		testFunc(funcT)
+		funcT.setContext(parent.ctx)
+		funcT.tracer, funcT.slot = parent.tracer, parent.slot
+		funcT.goroutines = parent.goroutines
+		funcT.goroutines.own()
+		funcT.environment = parent.environment
+		defer funcT.undoEnvironmentChanges()
+		defer funcT.tracer.span(name, "subtest", funcT.slot, t.Name(), time.Now())
+
+		if parent.interception == nil {
+			testFunc(funcT)
+			return
+		}
+
+		// The parent's interception also covers the subtest.
+		failed, message, skipped, _ := funcT.intercept(func() { testFunc(funcT) }).outcome()
+
+		switch {
+		case failed:
+			parent.interception.fail(message)
+			funcT.tb.Skip("testgroup: this subtest failed, but its failure was intercepted")
+		case skipped:
+			funcT.tb.SkipNow()
+		}
 	})
 }
 
+// Context returns a context that is canceled after the test and its subtests finish. For the test
+// methods of a group that uses the FailFast option, it's also canceled when another method fails.
+func (t *T) Context() context.Context {
+	return t.ctx
+}
+
+func (t *T) setContext(parent context.Context) {
+	ctx, cancel := context.WithCancel(parent)
+	t.tb.Cleanup(cancel)
+	t.ctx = ctx
+}
+
 // RunSerially runs the test methods of a group sequentially in lexicographic order.
-func (t *T) RunSerially(group interface{}) {
-	t.T.Helper()
-	RunSerially(t.T, group)
+func (t *T) RunSerially(group interface{}, opts ...Option) {
+	t.tb.Helper()
+	RunSeriallyOn(t.tb, group, opts...)
 }
 
 // RunInParallel runs the test methods of a group simultaneously and waits for all of them to
 // complete before returning.
-func (t *T) RunInParallel(group interface{}) {
-	t.T.Helper()
-	RunInParallel(t.T, group)
+func (t *T) RunInParallel(group interface{}, opts ...Option) {
+	t.tb.Helper()
+	RunInParallelOn(t.tb, group, opts...)
+}
+
+// Name is just like testing.T.Name.
+func (t *T) Name() string {
+	return t.tb.Name()
+}
+
+// Failed is just like testing.T.Failed.
+func (t *T) Failed() bool {
+	return t.tb.Failed()
+}
+
+// Skipped is just like testing.T.Skipped.
+func (t *T) Skipped() bool {
+	return t.tb.Skipped()
+}
+
+// Cleanup is just like testing.T.Cleanup.
+func (t *T) Cleanup(f func()) {
+	t.tb.Cleanup(f)
+}
+
+// TempDir is just like testing.T.TempDir.
+func (t *T) TempDir() string {
+	return t.tb.TempDir()
+}
+
+// Deadline is just like testing.T.Deadline. When the group runs on a TB that isn't a *testing.T,
+// it returns the deadline of the TB's Context, like the Context of a Recorder that standalone.Run
+// runs the group on.
+func (t *T) Deadline() (deadline time.Time, ok bool) {
+	if tt, ok := t.tb.(testingT); ok {
+		return tt.Deadline()
+	}
+
+	return testContext(t.tb).Deadline()
+}
+
+// Parallel is just like testing.T.Parallel.
+func (t *T) Parallel() {
+	t.tb.Parallel()
+}
+
+// Log is just like testing.T.Log, but respects the BufferOutput option.
+func (t *T) Log(args ...interface{}) {
+	t.tb.Helper()
+	t.log(fmt.Sprintln(args...))
+}
+
+// Logf is just like testing.T.Logf, but respects the BufferOutput option.
+func (t *T) Logf(format string, args ...interface{}) {
+	t.tb.Helper()
+	t.log(fmt.Sprintf(format, args...))
+}
+
+// Fatal is just like testing.T.Fatal, but respects the BufferOutput option.
+func (t *T) Fatal(args ...interface{}) {
+	t.tb.Helper()
+	t.log(fmt.Sprintln(args...))
+	t.failNow(fmt.Sprintln(args...))
+}
+
+// Fatalf is just like testing.T.Fatalf, but respects the BufferOutput option.
+func (t *T) Fatalf(format string, args ...interface{}) {
+	t.tb.Helper()
+	t.log(fmt.Sprintf(format, args...))
+	t.failNow(fmt.Sprintf(format, args...))
+}
+
+// Skip is just like testing.T.Skip, but respects the BufferOutput option.
+func (t *T) Skip(args ...interface{}) {
+	t.tb.Helper()
+	t.log(fmt.Sprintln(args...))
+	t.skipNow(fmt.Sprintln(args...))
+}
+
+// Skipf is just like testing.T.Skipf, but respects the BufferOutput option.
+func (t *T) Skipf(format string, args ...interface{}) {
+	t.tb.Helper()
+	t.log(fmt.Sprintf(format, args...))
+	t.skipNow(fmt.Sprintf(format, args...))
+}
+
+// SkipNow is just like testing.T.SkipNow.
+func (t *T) SkipNow() {
+	t.tb.Helper()
+	t.skipNow("")
 }
 
-func run(t *testing.T, parallel bool, group interface{}) {
+// log writes a message from the caller of one of T's logging methods.
+func (t *T) log(message string) {
+	t.tb.Helper()
+
+	if t.output == nil {
+		t.tb.Log(strings.TrimSuffix(message, "\n"))
+		return
+	}
+
+	t.output.write(callerLocation(2), message)
+}
+
+// assertionT is what T's testify assertions report failures to. It embeds the TB so that testify's
+// calls to Helper mark the right stack frames.
+type assertionT struct {
+	TB
+	t *T
+}
+
+func (a assertionT) Errorf(format string, args ...interface{}) {
+	a.t.tb.Helper()
+
+	message := fmt.Sprintf(format, args...)
+
+	if a.t.interception != nil {
+		a.t.interception.fail(message)
+
+		if a.t.output == nil {
+			a.t.tb.Log(message)
+		} else {
+			a.t.output.write("", message)
+		}
+
+		return
+	}
+
+	a.t.result.recordFailure(message)
+
+	if a.t.output == nil {
+		a.t.tb.Errorf(format, args...)
+		return
+	}
+
+	a.t.output.write("", message)
+	a.t.tb.Fail()
+}
+
+func (a assertionT) FailNow() {
+	a.t.tb.Helper()
+
+	if a.t.interception != nil {
+		runtime.Goexit() // Errorf already recorded the failure
+	}
+
+	a.t.tb.FailNow()
+}
+
+func run(t TB, parallel bool, group interface{}, opts *options) {
 	t.Helper()
+	newRunner(t, parallel, group, opts).run(t)
+}
+
+func newRunner(t TB, parallel bool, group interface{}, opts *options) *runner {
+	_, onTestingT := t.(testingT)
 
-	groupT := &T{
-		T:          t,
-		Assertions: assert.New(t),
-		Require:    require.New(t),
+	return &runner{
+		group:      group,
+		parallel:   parallel,
+		opts:       opts,
+		result:     newGroupResult(t, parallel),
+		onTestingT: onTestingT,
+
+		goroutineLeaks: goroutineLeaks{reported: map[uint64]bool{}},
+		fileLeaks:      fileLeaks{reported: map[string]bool{}},
 	}
+}
 
-	testMethods := findTestMethods(t, group)
-	if len(testMethods) == 0 {
+// runner runs one group.
+type runner struct {
+	group    interface{}
+	parallel bool
+	scenario bool
+	opts     *options
+	methods  []testMethod
+	result   *groupResult
+	tracer   *tracer
+	decls    *declarations
+	limits   *limits
+	locks    *resourceLocks
+	failFast *failFast
+	focused  map[string]bool // nil unless some methods are focused
+	only     map[string]bool // nil unless FindOrderDependence is running some of the methods
+
+	environment    *environmentGuard
+	goroutineLeaks goroutineLeaks
+	fileLeaks      fileLeaks
+
+	onTestingT bool // false when the group runs on another TB, like a Recorder
+}
+
+func (r *runner) run(t TB) {
+	t.Helper()
+
+	if r.opts.tracePath != "" {
+		r.tracer = newTracer(r.opts.tracePath, t.Name())
+		defer r.writeTrace(t, time.Now())
+	}
+
+	if r.onTestingT {
+		// Groups run on a Recorder aren't part of the process's reports.
+		defer r.result.finished()
+	}
+
+	groupT := newT(t, false, r.result.hooks)
+	groupT.setContext(testContext(t))
+	r.failFast = newFailFast(t, r.opts.failFast)
+	r.environment = newEnvironmentGuard(r.opts.guardEnvironment)
+	groupT.environment = r.environment
+
+	r.methods = findTestMethods(t, r.group)
+	if len(r.methods) == 0 {
 		t.Fatalf(
 			"testgroup: no tests found for %T."+
 				" Make sure your test methods are exported and that their receiver types"+
 				" match what you passed to testgroup.",
-			group)
+			r.group)
+	}
+
+	r.decls = findDeclarations(t, r.group, r.methods)
+	r.onlyMethods()
+
+	if r.scenario {
+		r.methods = stepOrder(r.methods, r.decls.dependsOn)
 	}
 
+	r.focused = r.findFocus(t)
+
+	r.limits = newLimits(r.opts)
+	r.locks = newResourceLocks()
+
+	r.skipGroupIfNeeded(groupT)
+
+	defer r.result.logSummary(t)
+
+	groupT.goroutines = r.snapshotGoroutines()
+	defer r.checkGoroutines(groupT, r.result.hooks, groupT.goroutines)
+	defer r.checkFiles(groupT, r.result.hooks, r.snapshotFiles(false))
+	defer r.checkGlobals(groupT, r.result.hooks, r.snapshotGlobals(false))
+	defer groupT.undoEnvironmentChanges()
+
 	type preGrouper interface{ PreGroup(t *T) }
 
-	if pg, ok := group.(preGrouper); ok {
-		pg.PreGroup(groupT)
+	if pg, ok := r.group.(preGrouper); ok && !r.isolating() {
+		r.runPhase(t, r.result.hooks, 0, "PreGroup", func() { pg.PreGroup(groupT) })
 	}
 
 	type postGrouper interface{ PostGroup(t *T) }
 
-	if pg, ok := group.(postGrouper); ok {
-		defer pg.PostGroup(groupT)
+	if pg, ok := r.group.(postGrouper); ok && !r.isolating() {
+		defer r.runPhase(t, r.result.hooks, 0, "PostGroup", func() { pg.PostGroup(groupT) })
 	}
 
-	if parallel {
-		// wrap in a t.Run to wait for the parallel tests to finish
-		t.Run(RunInParallelParentTestName, func(t *testing.T) {
-			runAllTests(t, parallel, group, testMethods)
-		})
-	} else {
-		runAllTests(t, parallel, group, testMethods)
+	if !r.parallel {
+		r.runTests(t, parallelPhase)
+		return
+	}
+
+	r.runTests(t, BeforeParallel)
+
+	if r.hasParallelTests() {
+		r.runParallelTests(t, groupT)
 	}
+
+	r.runTests(t, AfterParallel)
 }
 
-func runAllTests(t *testing.T, parallel bool, group interface{}, testMethods []testMethod) {
+// runParallelTests runs the tests that run in parallel, then checks the environment and globals
+// that they changed, since the changes can't be blamed on any one of them.
+func (r *runner) runParallelTests(t TB, groupT *T) {
 	t.Helper()
 
-	for _, m := range testMethods {
-		method := m
-		t.Run(method.Name, func(t *testing.T) {
+	globals := r.snapshotGlobals(false)
+
+	// wrap in a t.Run to wait for the parallel tests to finish
+	t.Run(RunInParallelParentTestName, func(t TB) { r.runTests(t, parallelPhase) })
+
+	r.result.hooks.runPhase(t, "parallel tests", func() {
+		r.environment.check(t, r.result.hooks, "the tests that ran in parallel")
+	})
+
+	r.checkGlobals(groupT, r.result.hooks, globals)
+}
+
+// skipGroupIfNeeded skips the whole group if its SkipGroup hook gives a reason to.
+func (r *runner) skipGroupIfNeeded(t *T) {
+	t.tb.Helper()
+
+	type groupSkipper interface{ SkipGroup(t *T) string }
+
+	sg, ok := r.group.(groupSkipper)
+	if !ok {
+		return
+	}
+
+	reason := ""
+	r.runPhase(t.tb, r.result.hooks, 0, "SkipGroup", func() { reason = sg.SkipGroup(t) })
+
+	if reason != "" {
+		t.Skipf("testgroup: skipping the group: %s", reason)
+	}
+}
+
+// runTests runs the group's test methods in the given phase as subtests of t. In RunSerially
+// groups, every method is in parallelPhase, but they don't run in parallel.
+func (r *runner) runTests(t TB, phase SerialPhase) {
+	t.Helper()
+
+	parallel := r.parallel && phase == parallelPhase
+
+	for i, m := range r.methods {
+		if r.parallel && r.decls.serial[m.Name] != phase {
+			continue
+		}
+
+		index, method := i, m
+		result := r.result.newTest(method.Name, parallel)
+
+		t.Run(method.Name, func(t TB) {
 			if parallel {
+				waitStart := time.Now()
+
 				t.Parallel()
+				r.tracer.waited(index, method.Name, t.Name(), "parallel", waitStart)
 			}
 
-			methodT := &T{
-				T:          t,
-				Assertions: assert.New(t),
-				Require:    require.New(t),
-			}
+			r.runTest(t, index, method, result)
+		})
+	}
+}
 
-			type preTester interface{ PreTest(t *T) }
-			if pt, ok := group.(preTester); ok {
-				pt.PreTest(methodT)
-			}
+func (r *runner) runTest(t TB, index int, method testMethod, result *testResult) {
+	t.Helper()
 
-			type postTester interface{ PostTest(t *T) }
-			if pt, ok := group.(postTester); ok {
-				defer pt.PostTest(methodT)
-			}
+	methodT := newT(t, r.opts.bufferOutput, result)
+	if methodT.output != nil {
+		defer methodT.output.flush()
+	}
 
-			method.Method.Call([]reflect.Value{reflect.ValueOf(methodT)})
-		})
+	result.started()
+	defer result.finished(t)
+
+	r.skipIfUnfocused(methodT, method.Name)
+	r.skipIfStopped(methodT)
+	r.skipIfPending(methodT, method.Name)
+
+	if r.scenario {
+		r.skipIfDependencyFailed(methodT, method.Name)
+	}
+
+	// Only methods that will run wait for their turn. Another method can fail while this one waits,
+	// so FailFast is checked again afterward, and the time spent waiting isn't counted.
+	defer r.waitForTurn(methodT, index, method.Name)()
+	r.skipIfStopped(methodT)
+	result.started()
+
+	methodT.setContext(r.failFast.ctx)
+	methodT.tracer, methodT.slot = r.tracer, r.tracer.acquireSlot()
+	defer r.tracer.releaseSlot(methodT.slot)
+	defer r.tracer.span(method.Name, "method", methodT.slot, t.Name(), time.Now())
+
+	methodT.goroutines = r.snapshotGoroutines()
+	defer r.checkGoroutines(methodT, result, methodT.goroutines)
+	defer r.checkFiles(methodT, result, r.snapshotFiles(result.parallel))
+	defer r.checkGlobals(methodT, result, r.snapshotGlobals(result.parallel))
+
+	methodT.environment = r.environment
+	defer methodT.undoEnvironmentChanges()
+
+	if r.isolating() {
+		r.runIsolated(methodT)
+		return
+	}
+
+	if policy := r.opts.retryPolicy(method.Name, r.decls); policy.retries > 0 {
+		r.runAttempts(methodT, method, result, policy)
+		return
+	}
+
+	r.runMethod(methodT, method, result)
+}
+
+// runMethod runs a test method with its PreTest and PostTest hooks.
+func (r *runner) runMethod(t *T, method testMethod, result *testResult) {
+	t.tb.Helper()
+
+	type preTester interface{ PreTest(t *T) }
+	if pt, ok := r.group.(preTester); ok {
+		r.runPhase(t.tb, result, t.slot, "PreTest", func() { pt.PreTest(t) })
+	}
+
+	type postTester interface{ PostTest(t *T) }
+	if pt, ok := r.group.(postTester); ok {
+		defer r.runPhase(t.tb, result, t.slot, "PostTest", func() { pt.PostTest(t) })
+	}
+
+	r.runPhase(t.tb, result, t.slot, "", func() {
+		body := func() { method.Method.Call([]reflect.Value{reflect.ValueOf(t)}) }
+
+		if reason, ok := r.decls.expectedFailures[method.Name]; ok {
+			expectFailure(t, r.group, reason, body)
+		} else {
+			body()
+		}
+	})
+}
+
+func (r *runner) hasParallelTests() bool {
+	for _, m := range r.methods {
+		if r.decls.serial[m.Name] == parallelPhase {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitForTurn waits until a test method's resources are free and the group's concurrency limits
+// allow it to run. It returns a function to call when the method is done.
+func (r *runner) waitForTurn(t *T, index int, method string) (done func()) {
+	t.tb.Helper()
+
+	waitStart := time.Now()
+
+	// Resources come first: waiting for them while holding a concurrency slot could deadlock.
+	lockWaited, unlock := r.locks.acquire(r.decls.resources[method])
+	if lockWaited > 0 {
+		r.tracer.waited(index, method, t.Name(), "resources", waitStart)
+
+		if verbose() {
+			t.Logf("testgroup: waited %v for resources used by other tests",
+				lockWaited.Round(time.Millisecond))
+		}
+	}
+
+	waitStart = time.Now()
+
+	limitWaited, release := r.limits.acquire(method, r.decls)
+	if limitWaited > 0 {
+		r.tracer.waited(index, method, t.Name(), "concurrency limit", waitStart)
+
+		if verbose() {
+			t.Logf("testgroup: waited %v for the group's concurrency limit",
+				limitWaited.Round(time.Millisecond))
+		}
+	}
+
+	return func() {
+		release()
+		unlock()
+	}
+}
+
+// runPhase runs a hook, or the test method itself if phase is "", and records what happened.
+func (r *runner) runPhase(t TB, result *testResult, slot int, phase string, f func()) {
+	t.Helper()
+
+	if phase == "" {
+		defer r.tracer.span("body", "test", slot, t.Name(), time.Now())
+	} else {
+		defer r.tracer.span(phase, "hook", slot, t.Name(), time.Now())
+	}
+
+	defer r.failFast.check(result, phase)
+
+	if p, ok := t.(phaseRecorder); ok {
+		p.recordPhase(phase)
+	}
+
+	result.runPhase(t, phase, func() {
+		defer r.environment.checkPhase(t, result, phase)
+		f()
+	})
+}
+
+func (r *runner) writeTrace(t TB, start time.Time) {
+	t.Helper()
+
+	r.tracer.span(t.Name(), "group", 0, t.Name(), start)
+
+	if err := r.tracer.write(); err != nil {
+		t.Errorf("testgroup: %v", err)
 	}
 }
 
@@ -181,7 +665,7 @@ type testMethod struct {
 	Method reflect.Value
 }
 
-func findTestMethods(t *testing.T, group interface{}) []testMethod {
+func findTestMethods(t TB, group interface{}) []testMethod {
 	t.Helper()
 
 	tests := []testMethod{}
@@ -195,6 +679,8 @@ func findTestMethods(t *testing.T, group interface{}) []testMethod {
 
 	testingTSignature := reflect.TypeOf(func(*testing.T) {})
 
+	benchmarkSignature := reflect.TypeOf(func(*B) {})
+
 	for i := 0; i < groupType.NumMethod(); i++ {
 		method := groupType.Method(i)
 		methodShortName := method.Name
@@ -203,6 +689,19 @@ func findTestMethods(t *testing.T, group interface{}) []testMethod {
 		methodValue := groupValue.Method(i)
 		methodSignature := methodValue.Type()
 
+		// A method named like a declaration is still a test if it has a test's signature, so that
+		// groups written before the declaration existed keep working.
+		declarationSignature, ok := declarationSignatures()[methodShortName]
+		if ok && methodSignature != expectedTestSignature {
+			if methodSignature != declarationSignature {
+				t.Errorf(
+					"testgroup: %v describes the group's tests, so its signature should be %v.",
+					methodFullName, declarationSignature)
+			}
+
+			continue
+		}
+
 		switch methodSignature {
 		case expectedTestSignature:
 			switch methodShortName {
@@ -214,6 +713,8 @@ func findTestMethods(t *testing.T, group interface{}) []testMethod {
 					Method: methodValue,
 				})
 			}
+		case benchmarkSignature:
+			// Benchmarks and their hooks run with RunBenchmarks.
 		case testingTSignature:
 			// This case is separate from the default just so we can give a little more help to the
 			// test writer.
@@ -234,7 +735,7 @@ func findTestMethods(t *testing.T, group interface{}) []testMethod {
 	return tests
 }
 
-func requireGroupAndGroupPtrMethodsToMatch(t *testing.T, groupType reflect.Type) {
+func requireGroupAndGroupPtrMethodsToMatch(t TB, groupType reflect.Type) {
 	t.Helper()
 
 	if groupType.Kind() == reflect.Ptr {
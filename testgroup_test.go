@@ -15,12 +15,8 @@
 package testgroup_test
 
 import (
-	"bufio"
-	"bytes"
 	"context"
-	"errors"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -209,6 +206,20 @@ func (g *ThingsYouCanDoWithT) RunSubtests(t *testgroup.T) {
 	}
 }
 
+func (g *ThingsYouCanDoWithT) UseContext(t *testgroup.T) {
+	t.NoError(t.Context().Err())
+
+	var subtestContext context.Context
+
+	t.Run("Subtest", func(t *testgroup.T) {
+		subtestContext = t.Context()
+		t.NoError(subtestContext.Err())
+	})
+
+	t.Equal(context.Canceled, subtestContext.Err())
+	t.NoError(t.Context().Err())
+}
+
 type Subgroup struct {
 	Count int32
 }
@@ -236,10 +247,7 @@ func (g *ThingsYouCanDoWithT) RunSubgroupInParallel(t *testgroup.T) {
 // The erroring tests are located in a separate file guarded by a build tag so that they aren't part
 // of the regular set of tests.
 func Test_Errors(t *testing.T) {
-	tests, err := findErrorTests()
-	if err != nil {
-		t.Fatalf("failed to find error tests: %v", err)
-	}
+	tests := testgrouptest.ListTests(t, "^Test_Error_", testgrouptest.Tags("testgroup_errors"))
 
 	for _, tn := range tests {
 		testName := tn
@@ -247,68 +255,20 @@ func Test_Errors(t *testing.T) {
 	}
 }
 
-func findErrorTests() ([]string, error) {
-	const prefix = "Test_Error_"
-
-	ctx := context.Background()
-
-	cmd := exec.CommandContext(ctx,
-		"go", "test",
-		"-tags", "testgroup_errors",
-		"-list", "^"+prefix)
-
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("%w: combined output:\n%s", err, out)
-	}
-
-	tests := []string{}
-
-	scanner := bufio.NewScanner(bytes.NewReader(out))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, prefix) {
-			tests = append(tests, strings.TrimSpace(line))
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error scanning for error tests: %w", err)
-	}
-
-	return tests, nil
-}
-
 func runTestExpectingFailure(t *testing.T, testName string) {
 	t.Helper()
 
-	ctx := context.Background()
-
-	//nolint:gosec // no risk using the testName param
-	cmd := exec.CommandContext(ctx,
-		"go", "test",
-		"-tags", "testgroup_errors",
-		"-run", fmt.Sprintf("^%s$", testName),
-	)
-
-	if raceDetectorEnabled {
-		cmd.Args = append(cmd.Args, "-race")
-	}
-
-	cmd.Args = append(cmd.Args, goTestCoverageArgs(t.Name())...)
-
-	t.Logf("cmd.Args: %v", cmd.Args)
+	result := testgrouptest.RunGoTest(t, fmt.Sprintf("^%s$", testName),
+		testgrouptest.Tags("testgroup_errors"),
+		testgrouptest.Args(goTestCoverageArgs(t.Name())...))
 
-	out, err := cmd.CombinedOutput()
-	var exitErr *exec.ExitError
-	if err != nil && errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+	if test := result.Test(testName); test != nil && test.Outcome == testgrouptest.Failed {
 		// It failed, as expected.
 		return
 	}
 
 	t.Logf("expected test to fail!")
-	t.Logf("cmd.Args: %#v", cmd.Args)
-	t.Logf("err: %v", err)
-	t.Logf("combined output:\n%s", out)
+	t.Logf("exit code: %d", result.ExitCode)
+	t.Logf("output:\n%s", result.Output)
 	t.FailNow()
 }
@@ -0,0 +1,78 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgrouptest
+
+import (
+	"strings"
+
+	"github.com/bloomberg/go-testgroup"
+)
+
+// RunSerially runs a group like testgroup.RunSerially, but in this process, on a
+// testgroup.Recorder named name, and returns what happened. Since a Recorder isn't connected to
+// the testing package, the group's failures don't fail the test that runs it.
+func RunSerially(name string, group interface{}, opts ...testgroup.Option) *Result {
+	return FromRecorder(testgroup.Record(name, func(t testgroup.TB) {
+		testgroup.RunSeriallyOn(t, group, opts...)
+	}))
+}
+
+// RunInParallel runs a group like testgroup.RunInParallel, but in this process, on a
+// testgroup.Recorder named name, and returns what happened.
+func RunInParallel(name string, group interface{}, opts ...testgroup.Option) *Result {
+	return FromRecorder(testgroup.Record(name, func(t testgroup.TB) {
+		testgroup.RunInParallelOn(t, group, opts...)
+	}))
+}
+
+// FromRecorder returns what happened to a recorded test and its subtests. Their Elapsed times are
+// zero, and ExitCode is 1 if the test failed.
+func FromRecorder(rec *testgroup.Recorder) *Result {
+	result := &Result{byName: map[string]*Test{}}
+	output := map[string]*strings.Builder{}
+
+	var all strings.Builder
+
+	for _, e := range rec.Events() {
+		switch e.Kind {
+		case testgroup.EventLog:
+			out, ok := output[e.Test]
+			if !ok {
+				out = &strings.Builder{}
+				output[e.Test] = out
+			}
+
+			out.WriteString(e.Message + "\n")
+			all.WriteString(e.Test + ": " + e.Message + "\n")
+
+		case testgroup.EventDone:
+			test := &Test{Name: e.Test, Outcome: Outcome(e.Message)}
+			if out, ok := output[e.Test]; ok {
+				test.Output = out.String()
+			}
+
+			result.Tests = append(result.Tests, test)
+			result.byName[e.Test] = test
+		}
+	}
+
+	result.Output = all.String()
+
+	if rec.Failed() {
+		result.ExitCode = 1
+	}
+
+	return result
+}
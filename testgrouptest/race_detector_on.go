@@ -1,4 +1,4 @@
-// Copyright 2019 Bloomberg Finance L.P.
+// Copyright 2026 Bloomberg Finance L.P.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,6 +15,8 @@
 //go:build race
 // +build race
 
-package testgroup_test
+package testgrouptest
 
-const raceDetectorEnabled = true
+// raceEnabled reports whether this binary was built with the race detector, so that the tests that
+// RunGoTest runs are too.
+const raceEnabled = true
@@ -0,0 +1,180 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgrouptest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup/internal/rerun"
+	"github.com/bloomberg/go-testgroup/internal/testjson"
+)
+
+// subprocessEnvVar is set in the subprocesses that Rerun starts.
+const subprocessEnvVar = "TESTGROUPTEST_SUBPROCESS"
+
+// InSubprocess reports whether the current test binary was started by Rerun. A test that checks
+// that something fails does the failing part when InSubprocess returns true, and checks the result
+// of Rerun otherwise.
+func InSubprocess() bool {
+	return os.Getenv(subprocessEnvVar) != ""
+}
+
+// Rerun runs t again in a new test process, where InSubprocess returns true, and returns what
+// happened to t and its subtests there. args are passed to the test binary, like "-test.short".
+//
+// It uses "go tool test2json" to read the subprocess's output, so the go command must be on the
+// PATH.
+func Rerun(t testing.TB, args ...string) *Result {
+	t.Helper()
+
+	args = append(append([]string{"tool", "test2json", "-t", os.Args[0]}, rerun.Args(t.Name())...), args...)
+
+	cmd := exec.CommandContext(context.Background(), "go", args...)
+	cmd.Env = append(os.Environ(), subprocessEnvVar+"="+t.Name())
+
+	return run(t, cmd)
+}
+
+// RunGoTest runs "go test -json -run pattern" for the package in the current directory, or the
+// one given by the Dir option, and returns what happened to the tests that ran. Use it for tests
+// that are only built with build tags, so that they don't run with the package's other tests.
+//
+// If the current test binary was built with the race detector, so are the tests that RunGoTest
+// runs.
+func RunGoTest(t testing.TB, pattern string, opts ...Option) *Result {
+	t.Helper()
+
+	o := newOptions(opts)
+
+	args := append([]string{"test", "-json", "-run", pattern}, o.goTestArgs()...)
+	args = append(args, o.args...)
+
+	//nolint:gosec // the arguments come from the test
+	cmd := exec.CommandContext(context.Background(), "go", args...)
+	cmd.Dir = o.dir
+
+	return run(t, cmd)
+}
+
+// ListTests returns the names of the tests, benchmarks, and examples that match pattern in the
+// package in the current directory, or the one given by the Dir option, like "go test -list".
+func ListTests(t testing.TB, pattern string, opts ...Option) []string {
+	t.Helper()
+
+	o := newOptions(opts)
+
+	args := append([]string{"test", "-list", pattern}, o.goTestArgs()...)
+
+	//nolint:gosec // the arguments come from the test
+	cmd := exec.CommandContext(context.Background(), "go", args...)
+	cmd.Dir = o.dir
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("testgrouptest: could not list the tests: %v\n%s", err, out)
+	}
+
+	names := []string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" && !strings.ContainsAny(line, " \t") {
+			names = append(names, line)
+		}
+	}
+
+	return names
+}
+
+// Option changes how RunGoTest and ListTests run "go test".
+type Option func(*options)
+
+type options struct {
+	dir  string
+	tags []string
+	args []string
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// goTestArgs returns the arguments that build the tests the same way for running and listing them.
+func (o *options) goTestArgs() []string {
+	var args []string
+
+	if len(o.tags) > 0 {
+		args = append(args, "-tags", strings.Join(o.tags, ","))
+	}
+
+	if raceEnabled {
+		args = append(args, "-race")
+	}
+
+	return args
+}
+
+// Dir runs "go test" in the given directory instead of the current one.
+func Dir(dir string) Option {
+	return func(o *options) { o.dir = dir }
+}
+
+// Tags builds the tests with the given build tags.
+func Tags(tags ...string) Option {
+	return func(o *options) { o.tags = append(o.tags, tags...) }
+}
+
+// Args passes more arguments to "go test", like "-coverprofile".
+func Args(args ...string) Option {
+	return func(o *options) { o.args = append(o.args, args...) }
+}
+
+// run runs a command that writes "go test -json" events and collects them.
+func run(t testing.TB, cmd *exec.Cmd) *Result {
+	t.Helper()
+
+	out, err := cmd.CombinedOutput()
+
+	exitCode := 0
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case errors.As(err, &exitErr):
+		exitCode = exitErr.ExitCode()
+	case err != nil:
+		t.Fatalf("testgrouptest: could not run %v: %v\n%s", cmd.Args, err, out)
+	}
+
+	b := newResultBuilder()
+	if err := testjson.Decode(bytes.NewReader(out), b.add, b.addOther); err != nil {
+		t.Fatalf("testgrouptest: %v", err)
+	}
+
+	return b.finish(exitCode)
+}
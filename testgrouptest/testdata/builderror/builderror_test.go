@@ -1,4 +1,4 @@
-// Copyright 2019 Bloomberg Finance L.P.
+// Copyright 2026 Bloomberg Finance L.P.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -12,9 +12,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !race
-// +build !race
+package builderror
 
-package testgroup_test
+import "testing"
 
-const raceDetectorEnabled = false
+// Test_BuildError doesn't compile, for Test_RunGoTest_BuildError.
+func Test_BuildError(t *testing.T) {
+	missing()
+}
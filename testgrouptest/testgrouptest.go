@@ -0,0 +1,155 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testgrouptest helps test code built on testgroup, like shared base groups and custom
+// hooks, including checking that tests fail when they should.
+//
+// The testing package can't run a test that is expected to fail without failing the test that runs
+// it, so testgrouptest runs it in a subprocess and reports what happened to each of its subtests:
+//
+//	func TestMyHookFailsBadGroups(t *testing.T) {
+//		if testgrouptest.InSubprocess() {
+//			testgroup.RunSerially(t, &BadGroup{}, myHook())
+//			return
+//		}
+//
+//		result := testgrouptest.Rerun(t)
+//		assert.Equal(t, testgrouptest.Failed, result.Test("TestMyHookFailsBadGroups/Method").Outcome)
+//	}
+//
+// RunSerially and RunInParallel run a group in the current process instead, on a
+// testgroup.Recorder, which is faster, and report what happened the same way.
+package testgrouptest
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bloomberg/go-testgroup/internal/testjson"
+)
+
+// Outcome is how a test finished.
+type Outcome string
+
+// The outcomes of a test.
+const (
+	Passed  Outcome = testjson.ActionPass
+	Failed  Outcome = testjson.ActionFail
+	Skipped Outcome = testjson.ActionSkip
+)
+
+// Test is what happened to one test or subtest.
+type Test struct {
+	Name    string // the full name, like "TestGroup/Method"
+	Outcome Outcome
+	Elapsed time.Duration
+
+	// Output is what the test printed, including its log messages, failure messages, and skip
+	// reasons, but not the lines that the testing package prints when it starts and finishes.
+	Output string
+}
+
+// Result is what happened when testgrouptest ran some tests.
+type Result struct {
+	// Tests holds the tests and subtests that finished, in the order that they finished.
+	Tests []*Test
+
+	// Output is everything that was printed, including build errors.
+	Output string
+
+	// ExitCode is the exit status of the command that ran the tests, which is 0 if they all passed.
+	ExitCode int
+
+	byName map[string]*Test
+}
+
+// Test returns the test with the given full name, or nil if it didn't finish.
+func (r *Result) Test(name string) *Test {
+	return r.byName[name]
+}
+
+// Names returns the full names of the tests with the given outcome, in the order that they
+// finished.
+func (r *Result) Names(outcome Outcome) []string {
+	names := []string{}
+
+	for _, t := range r.Tests {
+		if t.Outcome == outcome {
+			names = append(names, t.Name)
+		}
+	}
+
+	return names
+}
+
+// resultBuilder collects "go test -json" events into a Result.
+type resultBuilder struct {
+	result *Result
+	output map[string]*strings.Builder
+	all    strings.Builder
+}
+
+func newResultBuilder() *resultBuilder {
+	return &resultBuilder{
+		result: &Result{byName: map[string]*Test{}},
+		output: map[string]*strings.Builder{},
+	}
+}
+
+func (b *resultBuilder) add(e testjson.Event) {
+	switch {
+	case e.Action == testjson.ActionBuildOutput:
+		b.all.WriteString(e.Output)
+
+	case e.Action == testjson.ActionOutput:
+		b.all.WriteString(e.Output)
+
+		if e.Test == "" || e.IsFraming() {
+			return
+		}
+
+		out, ok := b.output[e.Test]
+		if !ok {
+			out = &strings.Builder{}
+			b.output[e.Test] = out
+		}
+
+		out.WriteString(e.Output)
+
+	case e.IsFinal() && e.Test != "":
+		test := &Test{
+			Name:    e.Test,
+			Outcome: Outcome(e.Action),
+			Elapsed: time.Duration(e.Elapsed * float64(time.Second)),
+		}
+
+		if out, ok := b.output[e.Test]; ok {
+			test.Output = out.String()
+		}
+
+		b.result.Tests = append(b.result.Tests, test)
+		b.result.byName[e.Test] = test
+	}
+}
+
+func (b *resultBuilder) addOther(line string) {
+	b.all.WriteString(line + "\n")
+}
+
+func (b *resultBuilder) finish(exitCode int) *Result {
+	b.result.Output = b.all.String()
+	b.result.ExitCode = exitCode
+
+	return b.result
+}
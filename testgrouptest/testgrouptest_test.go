@@ -0,0 +1,129 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgrouptest_test
+
+import (
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Rerun(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunSerially(t, &OutcomesGroup{})
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+	t.Logf("output:\n%s", result.Output)
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, []string{"Test_Rerun/Passes"}, result.Names(testgrouptest.Passed))
+	assert.Equal(t, []string{"Test_Rerun/Fails", "Test_Rerun"}, result.Names(testgrouptest.Failed))
+	assert.Equal(t, []string{"Test_Rerun/Skips"}, result.Names(testgrouptest.Skipped))
+
+	fails := result.Test("Test_Rerun/Fails")
+	require.NotNil(t, fails)
+	assert.Contains(t, fails.Output, "failing on purpose")
+	assert.NotContains(t, fails.Output, "--- FAIL")
+
+	skips := result.Test("Test_Rerun/Skips")
+	require.NotNil(t, skips)
+	assert.Contains(t, skips.Output, "skipping on purpose")
+
+	assert.Nil(t, result.Test("Test_Rerun/Missing"))
+	assert.Contains(t, result.Output, "--- FAIL: Test_Rerun/Fails ")
+}
+
+func Test_Rerun_Subtest(t *testing.T) {
+	t.Run("Only", func(t *testing.T) {
+		if testgrouptest.InSubprocess() {
+			t.Log("ran in the subprocess")
+			return
+		}
+
+		result := testgrouptest.Rerun(t)
+
+		assert.Equal(t, 0, result.ExitCode)
+		assert.Equal(t, []string{"Test_Rerun_Subtest/Only", "Test_Rerun_Subtest"}, result.Names(testgrouptest.Passed))
+		assert.Contains(t, result.Test("Test_Rerun_Subtest/Only").Output, "ran in the subprocess")
+	})
+
+	t.Run("NotRerun", func(t *testing.T) {
+		if testgrouptest.InSubprocess() {
+			t.Fatal("ran in the subprocess")
+		}
+	})
+}
+
+func Test_RunGoTest(t *testing.T) {
+	result := testgrouptest.RunGoTest(t, "^Test_Tagged$", testgrouptest.Tags("testgrouptest_tagged"))
+	t.Logf("output:\n%s", result.Output)
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, []string{"Test_Tagged/Passes"}, result.Names(testgrouptest.Passed))
+	assert.Equal(t, []string{"Test_Tagged/Fails", "Test_Tagged"}, result.Names(testgrouptest.Failed))
+	assert.Equal(t, []string{"Test_Tagged/Skips"}, result.Names(testgrouptest.Skipped))
+	assert.Contains(t, result.Test("Test_Tagged/Fails").Output, "failing on purpose")
+}
+
+func Test_RunGoTest_BuildError(t *testing.T) {
+	result := testgrouptest.RunGoTest(t, ".", testgrouptest.Dir("testdata/builderror"))
+
+	assert.NotEqual(t, 0, result.ExitCode)
+	assert.Empty(t, result.Tests)
+	assert.Contains(t, result.Output, "undefined: missing")
+}
+
+func Test_ListTests(t *testing.T) {
+	assert.Empty(t, testgrouptest.ListTests(t, "^Test_Tagged$"))
+	assert.Equal(t, []string{"Test_Tagged"},
+		testgrouptest.ListTests(t, "^Test_Tagged$", testgrouptest.Tags("testgrouptest_tagged")))
+}
+
+func Test_RunSerially(t *testing.T) {
+	result := testgrouptest.RunSerially("Group", &OutcomesGroup{})
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, []string{"Group/Passes"}, result.Names(testgrouptest.Passed))
+	assert.Equal(t, []string{"Group/Fails", "Group"}, result.Names(testgrouptest.Failed))
+	assert.Equal(t, []string{"Group/Skips"}, result.Names(testgrouptest.Skipped))
+	assert.Equal(t, "failing on purpose\n", result.Test("Group/Fails").Output)
+	assert.Contains(t, result.Output, "Group/Skips: skipping on purpose\n")
+}
+
+func Test_RunInParallel(t *testing.T) {
+	result := testgrouptest.RunInParallel("Group", &OutcomesGroup{})
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, []string{"Group/_/Passes"}, result.Names(testgrouptest.Passed))
+	assert.ElementsMatch(t, []string{"Group/_/Fails", "Group/_", "Group"}, result.Names(testgrouptest.Failed))
+	assert.Equal(t, []string{"Group/_/Skips"}, result.Names(testgrouptest.Skipped))
+}
+
+type OutcomesGroup struct{}
+
+func (*OutcomesGroup) Passes(t *testgroup.T) {}
+
+func (*OutcomesGroup) Fails(t *testgroup.T) {
+	t.Fatal("failing on purpose")
+}
+
+func (*OutcomesGroup) Skips(t *testgroup.T) {
+	t.Skip("skipping on purpose")
+}
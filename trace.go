@@ -0,0 +1,251 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Trace records how long each hook, test method, and T.Run subtest of the group takes, and writes
+// the timeline to path in the Chrome trace event format. You can open the file in a trace viewer
+// such as https://ui.perfetto.dev or chrome://tracing.
+//
+// In the trace, each group is a process. Its first thread shows PreGroup and PostGroup, and the
+// other threads are the slots that test methods ran in, so the number of threads is the most
+// methods that were running at once. The time each method spent waiting to run, either paused by
+// the testing package in RunInParallel groups or held back by MaxConcurrency, is shown in a
+// separate "waiting" thread per method.
+//
+// Groups that are traced to the same path are written to the same file. The file is rewritten each
+// time one of those groups finishes.
+func Trace(path string) Option {
+	return func(o *options) { o.tracePath = path }
+}
+
+// traceWaitLaneOffset is added to a method's index to get the trace thread for its waiting span.
+const traceWaitLaneOffset = 10000
+
+// traceFiles holds the trace files written by the Trace option, so that groups traced to the same
+// path can be written together.
+//
+//nolint:gochecknoglobals // The Trace option writes per-process files.
+var traceFiles = struct {
+	sync.Mutex
+	byPath map[string]*traceFile
+}{byPath: map[string]*traceFile{}}
+
+// traceFile is the contents of a trace written by the Trace option.
+type traceFile struct {
+	path  string
+	start time.Time
+
+	mutex   sync.Mutex
+	events  []traceEvent
+	nextPID int
+}
+
+// traceEvent is an event in the Chrome trace event format. See
+// https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU for details.
+type traceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat,omitempty"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur,omitempty"`
+	PID       int               `json:"pid"`
+	TID       int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+// tracer records the spans of one group. A nil *tracer records nothing.
+type tracer struct {
+	file *traceFile
+	pid  int
+
+	mutex     sync.Mutex
+	slots     []bool // which slots are in use by running methods; slot 0 is for the group's hooks
+	waitLanes map[int]bool
+}
+
+func newTracer(path, groupName string) *tracer {
+	traceFiles.Lock()
+
+	file, ok := traceFiles.byPath[path]
+	if !ok {
+		file = &traceFile{path: path, start: time.Now()}
+		traceFiles.byPath[path] = file
+	}
+
+	traceFiles.Unlock()
+
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+
+	file.nextPID++
+	tr := &tracer{file: file, pid: file.nextPID, slots: []bool{true}, waitLanes: map[int]bool{}}
+
+	tr.addLocked(traceEvent{
+		Name: "process_name", Phase: "M", PID: tr.pid,
+		Args: map[string]string{"name": groupName},
+	})
+	tr.addLocked(traceEvent{
+		Name: "thread_name", Phase: "M", PID: tr.pid, TID: 0,
+		Args: map[string]string{"name": "group"},
+	})
+
+	return tr
+}
+
+func (tr *tracer) addLocked(e traceEvent) {
+	tr.file.events = append(tr.file.events, e)
+}
+
+// span records a span on the given thread that started at start and ends now.
+func (tr *tracer) span(name, category string, tid int, testName string, start time.Time) {
+	if tr == nil {
+		return
+	}
+
+	end := time.Now()
+
+	tr.file.mutex.Lock()
+	defer tr.file.mutex.Unlock()
+
+	tr.addLocked(traceEvent{
+		Name:      name,
+		Category:  category,
+		Phase:     "X",
+		Timestamp: start.Sub(tr.file.start).Microseconds(),
+		Duration:  end.Sub(start).Microseconds(),
+		PID:       tr.pid,
+		TID:       tid,
+		Args: map[string]string{
+			"test":      testName,
+			"goroutine": strconv.FormatUint(goroutineID(), 10),
+		},
+	})
+}
+
+// waited records the time a method spent waiting to run. reason is the name of the span, which
+// says what the method was waiting for.
+func (tr *tracer) waited(methodIndex int, methodName, testName, reason string, start time.Time) {
+	if tr == nil {
+		return
+	}
+
+	tid := traceWaitLaneOffset + methodIndex
+
+	tr.mutex.Lock()
+	if !tr.waitLanes[tid] {
+		tr.waitLanes[tid] = true
+
+		tr.file.mutex.Lock()
+		tr.addLocked(traceEvent{
+			Name: "thread_name", Phase: "M", PID: tr.pid, TID: tid,
+			Args: map[string]string{"name": "waiting: " + methodName},
+		})
+		tr.file.mutex.Unlock()
+	}
+	tr.mutex.Unlock()
+
+	tr.span(reason, "wait", tid, testName, start)
+}
+
+// acquireSlot returns the lowest-numbered thread that no running method is using.
+func (tr *tracer) acquireSlot() int {
+	if tr == nil {
+		return 0
+	}
+
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+
+	for i, used := range tr.slots {
+		if !used {
+			tr.slots[i] = true
+			return i
+		}
+	}
+
+	tr.slots = append(tr.slots, true)
+	slot := len(tr.slots) - 1
+
+	tr.file.mutex.Lock()
+	tr.addLocked(traceEvent{
+		Name: "thread_name", Phase: "M", PID: tr.pid, TID: slot,
+		Args: map[string]string{"name": fmt.Sprintf("slot %d", slot)},
+	})
+	tr.file.mutex.Unlock()
+
+	return slot
+}
+
+func (tr *tracer) releaseSlot(slot int) {
+	if tr == nil {
+		return
+	}
+
+	tr.mutex.Lock()
+	tr.slots[slot] = false
+	tr.mutex.Unlock()
+}
+
+// write writes the trace file, including the spans of every group traced to the same path.
+func (tr *tracer) write() error {
+	if tr == nil {
+		return nil
+	}
+
+	tr.file.mutex.Lock()
+	defer tr.file.mutex.Unlock()
+
+	var b bytes.Buffer
+
+	err := json.NewEncoder(&b).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{tr.file.events, "ms"})
+	if err != nil {
+		return fmt.Errorf("could not encode trace: %w", err)
+	}
+
+	if err := os.WriteFile(tr.file.path, b.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("could not write trace: %w", err)
+	}
+
+	return nil
+}
+
+// goroutineID returns the ID of the current goroutine, as shown in stack traces.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+
+	return id
+}
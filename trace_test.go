@@ -0,0 +1,113 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Trace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+
+	t.Run("Parallel", func(t *testing.T) {
+		testgroup.RunInParallel(t, &Traced{}, testgroup.Trace(path))
+	})
+	t.Run("Serial", func(t *testing.T) {
+		testgroup.RunSerially(t, &Traced{}, testgroup.Trace(path))
+	})
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var trace struct {
+		TraceEvents []struct {
+			Name string
+			Cat  string
+			Ph   string
+			Pid  int
+			Tid  int
+			Dur  int64
+			Args map[string]string
+		}
+	}
+	require.NoError(t, json.Unmarshal(contents, &trace))
+
+	spans := map[int]map[string]int{}
+	processNames := map[int]string{}
+
+	for _, e := range trace.TraceEvents {
+		switch e.Ph {
+		case "X":
+			if spans[e.Pid] == nil {
+				spans[e.Pid] = map[string]int{}
+			}
+
+			spans[e.Pid][e.Cat+":"+e.Name]++
+
+			if e.Cat == "hook" && (e.Name == "PreGroup" || e.Name == "PostGroup") {
+				assert.Equal(t, 0, e.Tid, "group hooks should be on the group thread")
+			}
+
+			if e.Cat == "method" {
+				assert.NotEqual(t, 0, e.Tid, "methods should be in a slot")
+				assert.NotEmpty(t, e.Args["goroutine"])
+			}
+		case "M":
+			if e.Name == "process_name" {
+				processNames[e.Pid] = e.Args["name"]
+			}
+		}
+	}
+
+	assert.Equal(t,
+		map[int]string{1: "Test_Trace/Parallel", 2: "Test_Trace/Serial"},
+		processNames)
+
+	for pid, name := range processNames {
+		expected := map[string]int{
+			"group:" + name:  1,
+			"hook:PreGroup":  1,
+			"hook:PostGroup": 1,
+			"hook:PreTest":   2,
+			"hook:PostTest":  2,
+			"method:A":       1,
+			"method:B":       1,
+			"test:body":      2,
+			"subtest:sub":    1,
+		}
+		if pid == 1 {
+			expected["wait:parallel"] = 2
+		}
+
+		assert.Equal(t, expected, spans[pid], name)
+	}
+}
+
+type Traced struct{}
+
+func (*Traced) PreGroup(t *testgroup.T)  {}
+func (*Traced) PostGroup(t *testgroup.T) {}
+func (*Traced) PreTest(t *testgroup.T)   {}
+func (*Traced) PostTest(t *testgroup.T)  {}
+
+func (*Traced) A(t *testgroup.T) {}
+func (*Traced) B(t *testgroup.T) { t.Run("sub", func(t *testgroup.T) {}) }
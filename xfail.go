@@ -0,0 +1,50 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup
+
+import "fmt"
+
+// expectFailure runs the body of a test method that the group's ExpectedFailures method lists,
+// like this:
+//
+//	func (*MyGroup) ExpectedFailures() map[string]string {
+//		return map[string]string{
+//			"RoundsHalfToEven": "https://github.com/example/project/issues/123",
+//		}
+//	}
+//
+// The body's failures are intercepted. The method passes if the body failed, and fails if the body
+// passed, so that someone notices when the bug is fixed. The method's hooks aren't expected to
+// fail.
+func expectFailure(t *T, group interface{}, reason string, body func()) {
+	t.tb.Helper()
+
+	failed, _, skipped, skipReason := t.intercept(body).outcome()
+
+	switch {
+	case skipped:
+		t.skipNow(skipReason)
+	case failed:
+		t.Logf("testgroup: failed as expected (%s)", reason)
+	default:
+		message := fmt.Sprintf(
+			"testgroup: unexpectedly passed, but %T.ExpectedFailures says it fails (%s)."+
+				" If it's fixed, remove it from ExpectedFailures.",
+			group, reason)
+
+		t.Log(message)
+		t.fail(message)
+	}
+}
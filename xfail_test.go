@@ -0,0 +1,75 @@
+// Copyright 2026 Bloomberg Finance L.P.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testgroup_test
+
+import (
+	"testing"
+
+	"github.com/bloomberg/go-testgroup"
+	"github.com/bloomberg/go-testgroup/testgrouptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExpectedFailures(t *testing.T) {
+	if testgrouptest.InSubprocess() {
+		testgroup.RunSerially(t, &ExpectedFailures{})
+		return
+	}
+
+	result := testgrouptest.Rerun(t)
+
+	for _, name := range []string{"Assertion", "Fatal", "Panic", "Require", "Subtest", "NotExpected"} {
+		assert.Equal(t, testgrouptest.Passed, subtest(t, result, name).Outcome, name)
+	}
+
+	assert.Contains(t, subtest(t, result, "Assertion").Output, "testgroup: failed as expected (bug 1)")
+	assert.Equal(t, testgrouptest.Skipped, subtest(t, result, "Skip").Outcome)
+
+	fixed := subtest(t, result, "Fixed")
+	assert.Equal(t, testgrouptest.Failed, fixed.Outcome)
+	assert.Contains(t, fixed.Output, "testgroup: unexpectedly passed, but "+
+		"*testgroup_test.ExpectedFailures.ExpectedFailures says it fails (bug 6).")
+	assert.Contains(t, subtest(t, result, "").Output,
+		"testgroup: 1 of 8 tests failed and 1 skipped in Test_ExpectedFailures")
+}
+
+type ExpectedFailures struct{}
+
+func (*ExpectedFailures) ExpectedFailures() map[string]string {
+	return map[string]string{
+		"Assertion": "bug 1",
+		"Fatal":     "bug 2",
+		"Panic":     "bug 3",
+		"Require":   "bug 4",
+		"Subtest":   "bug 5",
+		"Fixed":     "bug 6",
+		"Skip":      "bug 7",
+	}
+}
+
+func (*ExpectedFailures) PostTest(t *testgroup.T) { t.Log("PostTest ran") }
+
+func (*ExpectedFailures) Assertion(t *testgroup.T) { t.Equal(1, 2) }
+func (*ExpectedFailures) Fatal(t *testgroup.T)     { t.Fatal("broken") }
+func (*ExpectedFailures) Panic(t *testgroup.T)     { panic("broken") }
+func (*ExpectedFailures) Require(t *testgroup.T)   { t.Require.True(false) }
+func (*ExpectedFailures) Fixed(t *testgroup.T)     { t.Equal(1, 1) }
+func (*ExpectedFailures) Skip(t *testgroup.T)      { t.Skip("not today") }
+
+func (*ExpectedFailures) Subtest(t *testgroup.T) {
+	t.Run("sub", func(t *testgroup.T) { t.Equal(1, 2) })
+}
+
+func (*ExpectedFailures) NotExpected(t *testgroup.T) {}